@@ -0,0 +1,52 @@
+package statuscake
+
+import (
+	"time"
+)
+
+// Alert is a notification sent by statuscake when the Status of a Test changed.
+type Alert struct {
+	TestID int
+
+	// Triggered is when the Alert has been sent.
+	Triggered time.Time
+
+	// Status of the Test that triggered the Alert, Up or Down.
+	Status Status
+
+	// StatusCode returned by the tested website, 0 for tests not using HTTP.
+	StatusCode int
+
+	// ContactGroups are the IDs of the contact groups notified, when reported by the API.
+	ContactGroups []string
+}
+
+type alertResponse struct {
+	TestID        int                `json:"TestID"`
+	Triggered     string             `json:"Triggered"`
+	TriggeredUnix int64              `json:"Triggered_Unix"`
+	Unix          int64              `json:"Unix"`
+	Status        Status             `json:"Status"`
+	StatusCode    int                `json:"StatusCode"`
+	ContactGroups []jsonNumberString `json:"ContactGroups"`
+}
+
+func (a *alertResponse) alert() *Alert {
+	triggered := a.TriggeredUnix
+	if triggered == 0 {
+		triggered = a.Unix
+	}
+
+	var contactGroups []string
+	for _, id := range a.ContactGroups {
+		contactGroups = append(contactGroups, string(id))
+	}
+
+	return &Alert{
+		TestID:        a.TestID,
+		Triggered:     time.Unix(triggered, 0).UTC(),
+		Status:        a.Status,
+		StatusCode:    a.StatusCode,
+		ContactGroups: contactGroups,
+	}
+}
@@ -0,0 +1,269 @@
+// Package cassette records the HTTP interactions with the statuscake.com API
+// and replays them, so that code using the statuscake package can be tested offline.
+//
+//	// record real interactions
+//	rec := cassette.NewRecorder(nil)
+//	c, err := statuscake.New(auth, statuscake.WithHTTPClient(&http.Client{Transport: rec}))
+//	...
+//	err = rec.Save("fixtures/tests.json")
+//
+//	// replay them
+//	cas, err := cassette.Load("fixtures/tests.json")
+//	c, err := statuscake.New(auth, statuscake.WithHTTPClient(&http.Client{Transport: cassette.NewReplayer(cas)}))
+//
+// Credentials are scrubbed from the recorded requests.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/DreamItGetIT/statuscake"
+)
+
+// ErrNoInteraction is returned by a Replayer when no recorded interaction matches a request.
+var ErrNoInteraction = errors.New("no recorded interaction matches the request")
+
+// Request is a recorded HTTP request.
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Response is a recorded HTTP response.
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Status     string      `json:"status"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Interaction is a request and the response it got.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Cassette is a list of interactions, in the order they were recorded.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Load reads a Cassette from the JSON file at path.
+func Load(path string) (*Cassette, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var c Cassette
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("invalid cassette %s: %w", path, err)
+	}
+
+	return &c, nil
+}
+
+// Save writes c as JSON to the file at path.
+func (c *Cassette) Save(path string) error {
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, append(b, '\n'), 0644)
+}
+
+// Recorder is an http.RoundTripper sending the requests with another RoundTripper
+// and recording them, with the credentials scrubbed.
+type Recorder struct {
+	transport http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+}
+
+// NewRecorder returns a Recorder sending the requests with transport.
+// If transport is nil, http.DefaultTransport is used.
+func NewRecorder(transport http.RoundTripper) *Recorder {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return &Recorder{
+		transport: transport,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, sent, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.transport.RoundTrip(sent)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: scrubRequest(req, reqBody),
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			Header:     resp.Header.Clone(),
+			Body:       string(respBody),
+		},
+	})
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+// Cassette returns a copy of the recorded interactions.
+func (r *Recorder) Cassette() *Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return &Cassette{
+		Interactions: append([]Interaction(nil), r.cassette.Interactions...),
+	}
+}
+
+// Save writes the recorded interactions to the file at path.
+func (r *Recorder) Save(path string) error {
+	return r.Cassette().Save(path)
+}
+
+// Replayer is an http.RoundTripper serving the responses of a Cassette without sending any request.
+// Each request gets the response of the first interaction not replayed yet with the same method,
+// URL and body, so that the same request can get different responses.
+type Replayer struct {
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+}
+
+// NewReplayer returns a Replayer serving the responses of c.
+func NewReplayer(c *Cassette) *Replayer {
+	return &Replayer{
+		cassette: c,
+		used:     make([]bool, len(c.Interactions)),
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, _, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	sr := scrubRequest(req, body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, in := range r.cassette.Interactions {
+		if r.used[i] || in.Request.Method != sr.Method || in.Request.URL != sr.URL || in.Request.Body != sr.Body {
+			continue
+		}
+		r.used[i] = true
+
+		header := in.Response.Header.Clone()
+		if header == nil {
+			header = make(http.Header)
+		}
+
+		return &http.Response{
+			StatusCode:    in.Response.StatusCode,
+			Status:        in.Response.Status,
+			Header:        header,
+			Body:          ioutil.NopCloser(strings.NewReader(in.Response.Body)),
+			ContentLength: int64(len(in.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, sr.Method, sr.URL)
+}
+
+// readBody returns the body of req and the request to send in its place, leaving req untouched
+// as required by http.RoundTripper. The body is read from a copy returned by req.GetBody when
+// available, otherwise req.Body is consumed and a clone of req with the buffered body is returned.
+func readBody(req *http.Request) ([]byte, *http.Request, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, req, nil
+	}
+
+	if req.GetBody != nil {
+		rc, err := req.GetBody()
+		if err != nil {
+			return nil, nil, err
+		}
+		b, err := ioutil.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return b, req, nil
+	}
+
+	b, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sent := req.Clone(req.Context())
+	sent.Body = ioutil.NopCloser(bytes.NewReader(b))
+	sent.GetBody = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(b)), nil
+	}
+
+	return b, sent, nil
+}
+
+// scrubRequest returns the Request recorded for req, with the credentials redacted.
+func scrubRequest(req *http.Request, body []byte) Request {
+	u := *req.URL
+	if u.RawQuery != "" {
+		u.RawQuery = statuscake.RedactValues(u.Query()).Encode()
+	}
+
+	b := string(body)
+	if strings.HasPrefix(req.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		if v, err := url.ParseQuery(b); err == nil {
+			b = statuscake.RedactValues(v).Encode()
+		}
+	}
+
+	return Request{
+		Method: req.Method,
+		URL:    u.String(),
+		Header: statuscake.RedactHeader(req.Header),
+		Body:   b,
+	}
+}
@@ -0,0 +1,191 @@
+package cassette
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/DreamItGetIT/statuscake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newServer(t *testing.T) (*httptest.Server, *int) {
+	calls := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch r.URL.Path {
+		case "/Tests/Details":
+			fmt.Fprintf(w, `{"TestID":%s,"WebsiteName":"call %d","TestType":"HTTP","StatusCodes":[]}`, r.URL.Query().Get("TestID"), calls)
+		case "/Tests/Update":
+			fmt.Fprint(w, `{"Success":true,"Message":"Test Inserted","InsertID":1234}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(s.Close)
+
+	return s, &calls
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	s, calls := newServer(t)
+	auth := statuscake.Auth{Username: "random-user", Apikey: "my-pass"}
+
+	rec := NewRecorder(nil)
+	c, err := statuscake.New(auth, statuscake.WithBaseURL(s.URL), statuscake.WithHTTPClient(&http.Client{Transport: rec}))
+	require.Nil(err)
+
+	test, err := c.Tests().Detail(1)
+	require.Nil(err)
+	assert.Equal("call 1", test.WebsiteName)
+
+	test, err = c.Tests().Detail(1)
+	require.Nil(err)
+	assert.Equal("call 2", test.WebsiteName)
+
+	test, err = c.Tests().Update(&statuscake.Test{WebsiteName: "foo", BasicPass: "secret", TestType: "HTTP"})
+	require.Nil(err)
+	assert.Equal(1234, test.TestID)
+
+	p := filepath.Join(t.TempDir(), "cassette.json")
+	require.Nil(rec.Save(p))
+
+	b, err := ioutil.ReadFile(p)
+	require.Nil(err)
+	assert.NotContains(string(b), "my-pass")
+	assert.NotContains(string(b), "random-user")
+	assert.NotContains(string(b), "secret")
+
+	cas, err := Load(p)
+	require.Nil(err)
+	require.Len(cas.Interactions, 3)
+	assert.Equal("GET", cas.Interactions[0].Request.Method)
+	assert.Equal("REDACTED", cas.Interactions[0].Request.Header.Get("API"))
+
+	*calls = 0
+	c, err = statuscake.New(auth, statuscake.WithBaseURL(s.URL), statuscake.WithHTTPClient(&http.Client{Transport: NewReplayer(cas)}))
+	require.Nil(err)
+
+	test, err = c.Tests().Detail(1)
+	require.Nil(err)
+	assert.Equal("call 1", test.WebsiteName)
+
+	test, err = c.Tests().Detail(1)
+	require.Nil(err)
+	assert.Equal("call 2", test.WebsiteName)
+
+	test, err = c.Tests().Update(&statuscake.Test{WebsiteName: "foo", BasicPass: "another secret", TestType: "HTTP"})
+	require.Nil(err)
+	assert.Equal(1234, test.TestID)
+
+	assert.Equal(0, *calls)
+
+	_, err = c.Tests().Detail(1)
+	require.NotNil(err)
+	assert.True(errors.Is(err, ErrNoInteraction))
+}
+
+type captureTransport struct {
+	requests []*http.Request
+	bodies   []string
+}
+
+func (c *captureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	b, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	c.requests = append(c.requests, req)
+	c.bodies = append(c.bodies, string(b))
+
+	return &http.Response{StatusCode: 200, Body: ioutil.NopCloser(strings.NewReader("{}"))}, nil
+}
+
+type trackingBody struct {
+	io.Reader
+	closed bool
+}
+
+func (b *trackingBody) Close() error {
+	b.closed = true
+	return nil
+}
+
+func TestRecorder_DoesNotModifyRequest(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	ct := &captureTransport{}
+	rec := NewRecorder(ct)
+
+	// GetBody is set: the body is read from a copy and req is sent as is
+	req, err := http.NewRequest("PUT", "http://example.com/Tests/Update", strings.NewReader("WebsiteName=foo"))
+	require.Nil(err)
+	body := req.Body
+
+	_, err = rec.RoundTrip(req)
+	require.Nil(err)
+	assert.True(body == req.Body)
+	require.Len(ct.requests, 1)
+	assert.True(ct.requests[0] == req)
+	assert.Equal("WebsiteName=foo", ct.bodies[0])
+
+	// GetBody isn't set: the body is consumed and a clone of req is sent
+	tb := &trackingBody{Reader: strings.NewReader("WebsiteName=bar")}
+	req, err = http.NewRequest("PUT", "http://example.com/Tests/Update", tb)
+	require.Nil(err)
+
+	_, err = rec.RoundTrip(req)
+	require.Nil(err)
+	assert.True(tb == req.Body)
+	assert.True(tb.closed)
+	require.Len(ct.requests, 2)
+	assert.False(ct.requests[1] == req)
+	assert.Equal("WebsiteName=bar", ct.bodies[1])
+
+	cas := rec.Cassette()
+	assert.Equal("WebsiteName=foo", cas.Interactions[0].Request.Body)
+	assert.Equal("WebsiteName=bar", cas.Interactions[1].Request.Body)
+}
+
+func TestReplayer_DoesNotModifyRequest(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	r := NewReplayer(&Cassette{Interactions: []Interaction{{
+		Request:  Request{Method: "PUT", URL: "http://example.com/Tests/Update", Body: "WebsiteName=foo"},
+		Response: Response{StatusCode: 200, Body: "{}"},
+	}}})
+
+	tb := &trackingBody{Reader: strings.NewReader("WebsiteName=foo")}
+	req, err := http.NewRequest("PUT", "http://example.com/Tests/Update", tb)
+	require.Nil(err)
+
+	resp, err := r.RoundTrip(req)
+	require.Nil(err)
+	assert.Equal(200, resp.StatusCode)
+	assert.True(tb == req.Body)
+	assert.True(tb.closed)
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	p := filepath.Join(t.TempDir(), "cassette.json")
+	require.Nil(ioutil.WriteFile(p, []byte("nope"), 0644))
+
+	_, err := Load(p)
+	assert.NotNil(err)
+}
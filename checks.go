@@ -0,0 +1,85 @@
+package statuscake
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// defaultChecksPageSize is the number of Checks requested at once when CheckFilter.PageSize is 0.
+const defaultChecksPageSize = 1000
+
+// Check is the result of one check of a Test from one location.
+type Check struct {
+	Time time.Time
+
+	// Location is the ID of the node location running the check.
+	Location string
+
+	// StatusCode returned by the tested website.
+	StatusCode int
+
+	// Performance is the time taken by the tested website to respond.
+	Performance time.Duration
+}
+
+// CheckFilter selects the Checks returned by Tests.Checks.
+type CheckFilter struct {
+	// Start of the time range, the oldest Checks kept by the API when zero.
+	Start time.Time
+
+	// End of the time range, now when zero.
+	End time.Time
+
+	// Location, when set, only keeps the Checks run from this node location.
+	Location string
+
+	// PageSize is the number of Checks requested at once, 1000 when 0.
+	PageSize int
+}
+
+func (f *CheckFilter) pageSize() int {
+	if f.PageSize > 0 {
+		return f.PageSize
+	}
+
+	return defaultChecksPageSize
+}
+
+type checkResponse struct {
+	Time        int64  `json:"Time"`
+	Location    string `json:"Location"`
+	Status      int    `json:"Status"`
+	Performance int64  `json:"Performance"`
+}
+
+func (c *checkResponse) check() *Check {
+	return &Check{
+		Time:        time.Unix(c.Time, 0).UTC(),
+		Location:    c.Location,
+		StatusCode:  c.Status,
+		Performance: time.Duration(c.Performance) * time.Millisecond,
+	}
+}
+
+// checksResponse is a list of Checks. The API returns them in an object keyed by check ID.
+type checksResponse []*checkResponse
+
+func (cr *checksResponse) UnmarshalJSON(b []byte) error {
+	var byID map[string]*checkResponse
+	if err := json.Unmarshal(b, &byID); err != nil {
+		var list []*checkResponse
+		if json.Unmarshal(b, &list) != nil {
+			return err
+		}
+
+		*cr = list
+		return nil
+	}
+
+	*cr = make(checksResponse, 0, len(byID))
+	for _, c := range byID {
+		*cr = append(*cr, c)
+	}
+
+	return nil
+}
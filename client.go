@@ -2,13 +2,18 @@ package statuscake
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"log/slog"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
 const apiBaseURL = "https://app.statuscake.com/API"
@@ -50,64 +55,144 @@ type httpClient interface {
 }
 
 type apiClient interface {
-	get(string, url.Values) (*http.Response, error)
-	delete(string, url.Values) (*http.Response, error)
-	put(string, url.Values) (*http.Response, error)
+	get(context.Context, string, url.Values) (*http.Response, error)
+	delete(context.Context, string, url.Values) (*http.Response, error)
+	put(context.Context, string, url.Values) (*http.Response, error)
 }
 
 // Client is the http client that wraps the remote API.
 type Client struct {
 	c           httpClient
-	username    string
-	apiKey      string
-	testsClient Tests
+	baseURL     string
+	userAgent   string
+	timeout     time.Duration
+	retry       RetryPolicy
+	limiter     *rateLimiter
+	middlewares []Middleware
+	logger      *slog.Logger
+	creds       CredentialsProvider
+	readOnly    bool
+	dryRun      bool
+
+	dryRunMu       sync.Mutex
+	dryRunRequests []DryRunRequest
+
+	testsClient         Tests
+	sslsClient          Ssls
+	contactGroupsClient ContactGroups
 }
 
-// New returns a new Client
-func New(auth Auth) (*Client, error) {
-	if err := auth.validate(); err != nil {
-		return nil, err
+// New returns a new Client configured with the given options.
+// The requests are authenticated with auth, unless WithCredentials is used.
+func New(auth Auth, opts ...Option) (*Client, error) {
+	c := &Client{
+		c:       &http.Client{},
+		baseURL: apiBaseURL,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.creds == nil {
+		if err := auth.validate(); err != nil {
+			return nil, err
+		}
+
+		c.creds = StaticCredentials(auth)
+	}
+
+	if c.timeout > 0 {
+		if hc, ok := c.c.(*http.Client); ok {
+			hc2 := *hc
+			hc2.Timeout = c.timeout
+			c.c = &hc2
+		}
 	}
 
-	return &Client{
-		c:        &http.Client{},
-		username: auth.Username,
-		apiKey:   auth.Apikey,
-	}, nil
+	return c, nil
 }
 
-func (c *Client) newRequest(method string, path string, v url.Values, body io.Reader) (*http.Request, error) {
-	url := fmt.Sprintf("%s%s", apiBaseURL, path)
+func (c *Client) newRequest(ctx context.Context, method string, path string, v url.Values, body io.Reader) (*http.Request, error) {
+	url := fmt.Sprintf("%s%s", c.baseURL, path)
 	if v != nil {
 		url = fmt.Sprintf("%s?%s", url, v.Encode())
 	}
 
-	r, err := http.NewRequest(method, url, body)
+	r, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return nil, err
 	}
 
-	r.Header.Set("Username", c.username)
-	r.Header.Set("API", c.apiKey)
+	if err := c.authenticate(r); err != nil {
+		return nil, err
+	}
+
+	if c.userAgent != "" {
+		r.Header.Set("User-Agent", c.userAgent)
+	}
 
 	return r, nil
 }
 
 func (c *Client) doRequest(r *http.Request) (*http.Response, error) {
-	resp, err := c.c.Do(r)
+	attempts := c.retry.attempts(r)
+	reauthenticated := false
+
+	for attempt := 1; ; attempt++ {
+		if c.limiter != nil {
+			if err := c.limiter.wait(r.Context()); err != nil {
+				return nil, err
+			}
+		}
+
+		start := time.Now()
+		resp, err := c.doAttempt(r)
+		c.logAttempt(r, attempt, time.Since(start), resp, err)
+
+		if aerr, ok := err.(*AuthenticationError); ok && aerr.InvalidCredentials() && !reauthenticated {
+			// the credentials may have been rotated, try once more with fresh ones
+			reauthenticated = true
+			if r2, ok := c.reauthenticate(r); ok {
+				r = r2
+				attempt--
+				continue
+			}
+		}
+		if err == nil || attempt >= attempts || r.Context().Err() != nil || !c.retry.retryable(err) {
+			return resp, err
+		}
+
+		if err := sleep(r.Context(), c.retry.backoff(attempt, err)); err != nil {
+			return nil, err
+		}
+
+		r, err = rewindRequest(r)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (c *Client) doAttempt(r *http.Request) (*http.Response, error) {
+	resp, err := c.roundTrip(r)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		return nil, &httpError{
-			status:     resp.Status,
-			statusCode: resp.StatusCode,
+		// the body is only informative, so read errors are ignored
+		b, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxHTTPErrorBody+1))
+
+		if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+			if aerr := parseAuthenticationError(b); aerr != nil {
+				return nil, aerr
+			}
 		}
-	}
 
-	var aer autheticationErrorResponse
+		return nil, newHTTPError(r, resp, b)
+	}
 
 	// We read and save the response body so that if we don't have error messages
 	// we can set it again for future usage
@@ -116,14 +201,12 @@ func (c *Client) doRequest(r *http.Request) (*http.Response, error) {
 		return nil, err
 	}
 
-	err = json.Unmarshal(b, &aer)
-	if err == nil && aer.ErrNo == 0 && aer.Error != "" {
-		return nil, &AuthenticationError{
-			errNo:   aer.ErrNo,
-			message: aer.Error,
-		}
+	if aerr := parseAuthenticationError(b); aerr != nil {
+		return nil, aerr
 	}
 
+	c.logAPIError(r, b)
+
 	resp.Body = &responseBody{
 		Reader: bytes.NewReader(b),
 	}
@@ -131,32 +214,88 @@ func (c *Client) doRequest(r *http.Request) (*http.Response, error) {
 	return resp, nil
 }
 
-func (c *Client) get(path string, v url.Values) (*http.Response, error) {
-	r, err := c.newRequest("GET", path, v, nil)
+// parseAuthenticationError returns an AuthenticationError if b is an authentication error response, nil otherwise.
+func parseAuthenticationError(b []byte) *AuthenticationError {
+	var aer autheticationErrorResponse
+	if err := json.Unmarshal(b, &aer); err != nil || aer.ErrNo == nil || aer.Error == "" {
+		return nil
+	}
+
+	errNo, err := strconv.Atoi(string(*aer.ErrNo))
+	if err != nil {
+		return nil
+	}
+
+	return &AuthenticationError{
+		ErrNo:   errNo,
+		Message: aer.Error,
+	}
+}
+
+func newHTTPError(r *http.Request, resp *http.Response, body []byte) *HTTPError {
+	return &HTTPError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Header:     resp.Header,
+		Method:     r.Method,
+		Path:       r.URL.Path,
+		Body:       string(truncate(bytes.TrimSpace(body), maxHTTPErrorBody)),
+	}
+}
+
+// rewindRequest returns a copy of r that can be sent again, with a fresh body.
+func rewindRequest(r *http.Request) (*http.Request, error) {
+	r2 := r.Clone(r.Context())
+	if r.GetBody != nil {
+		body, err := r.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		r2.Body = body
+	}
+
+	return r2, nil
+}
+
+func (c *Client) get(ctx context.Context, path string, v url.Values) (*http.Response, error) {
+	r, err := c.newRequest(ctx, "GET", path, v, nil)
 	if err != nil {
 		return nil, err
 	}
 
+	c.logRequest(r, nil)
+
 	return c.doRequest(r)
 }
 
-func (c *Client) put(path string, v url.Values) (*http.Response, error) {
-	r, err := c.newRequest("PUT", path, nil, strings.NewReader(v.Encode()))
-	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+func (c *Client) put(ctx context.Context, path string, v url.Values) (*http.Response, error) {
+	if resp, err := c.checkWrite("PUT", path, v); resp != nil || err != nil {
+		return resp, err
+	}
 
+	r, err := c.newRequest(ctx, "PUT", path, nil, strings.NewReader(v.Encode()))
 	if err != nil {
 		return nil, err
 	}
 
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	c.logRequest(r, v)
+
 	return c.doRequest(r)
 }
 
-func (c *Client) delete(path string, v url.Values) (*http.Response, error) {
-	r, err := c.newRequest("DELETE", path, v, nil)
+func (c *Client) delete(ctx context.Context, path string, v url.Values) (*http.Response, error) {
+	if resp, err := c.checkWrite("DELETE", path, v); resp != nil || err != nil {
+		return resp, err
+	}
+
+	r, err := c.newRequest(ctx, "DELETE", path, v, nil)
 	if err != nil {
 		return nil, err
 	}
 
+	c.logRequest(r, nil)
+
 	return c.doRequest(r)
 }
 
@@ -168,3 +307,21 @@ func (c *Client) Tests() Tests {
 
 	return c.testsClient
 }
+
+// Ssls returns a client that implements the `Ssls` API.
+func (c *Client) Ssls() Ssls {
+	if c.sslsClient == nil {
+		c.sslsClient = newSsls(c)
+	}
+
+	return c.sslsClient
+}
+
+// ContactGroups returns a client that implements the `ContactGroups` API.
+func (c *Client) ContactGroups() ContactGroups {
+	if c.contactGroupsClient == nil {
+		c.contactGroupsClient = newContactGroups(c)
+	}
+
+	return c.contactGroupsClient
+}
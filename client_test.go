@@ -2,6 +2,8 @@ package statuscake
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"io"
 	"io/ioutil"
 	"log"
@@ -9,6 +11,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -44,8 +47,7 @@ func TestClient(t *testing.T) {
 	c, err := New(Auth{Username: "random-user", Apikey: "my-pass"})
 	require.Nil(err)
 
-	assert.Equal("random-user", c.username)
-	assert.Equal("my-pass", c.apiKey)
+	assert.Equal(StaticCredentials{Username: "random-user", Apikey: "my-pass"}, c.creds)
 }
 
 func TestClient_newRequest(t *testing.T) {
@@ -55,7 +57,7 @@ func TestClient_newRequest(t *testing.T) {
 	c, err := New(Auth{Username: "random-user", Apikey: "my-pass"})
 	require.Nil(err)
 
-	r, err := c.newRequest("GET", "/hello", nil, nil)
+	r, err := c.newRequest(context.Background(), "GET", "/hello", nil, nil)
 
 	require.Nil(err)
 	assert.Equal("GET", r.Method)
@@ -64,6 +66,21 @@ func TestClient_newRequest(t *testing.T) {
 	assert.Equal("my-pass", r.Header.Get("API"))
 }
 
+func TestClient_newRequest_WithContext(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	c, err := New(Auth{Username: "random-user", Apikey: "my-pass"})
+	require.Nil(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r, err := c.newRequest(ctx, "GET", "/hello", nil, nil)
+	require.Nil(err)
+
+	cancel()
+	assert.Equal(context.Canceled, r.Context().Err())
+}
+
 func TestClient_doRequest(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
@@ -101,7 +118,36 @@ func TestClient_doRequest_WithHTTPErrors(t *testing.T) {
 
 	_, err = c.doRequest(req)
 	require.NotNil(err)
-	assert.IsType(&httpError{}, err)
+	assert.IsType(&HTTPError{}, err)
+}
+
+func TestClient_doRequest_HTTPErrorDetails(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	c, err := New(Auth{Username: "random-user", Apikey: "my-pass"})
+	require.Nil(err)
+
+	hc := &sequenceHTTPClient{
+		responses: []fakeResponse{{
+			StatusCode: 400,
+			Header:     http.Header{"X-Foo": {"bar"}},
+			Body:       strings.Repeat("a", 2000),
+		}},
+	}
+	c.c = hc
+
+	_, err = c.delete(context.Background(), "/Tests/Details", url.Values{"TestID": {"1"}})
+	require.NotNil(err)
+
+	var herr *HTTPError
+	require.True(errors.As(err, &herr))
+	assert.Equal(400, herr.StatusCode)
+	assert.Equal("bar", herr.Header.Get("X-Foo"))
+	assert.Equal("DELETE", herr.Method)
+	assert.Equal("/API/Tests/Details", herr.Path)
+	assert.Len(herr.Body, 1024)
+	assert.True(strings.HasSuffix(herr.Body, "..."))
 }
 
 func TestClient_doRequest_HttpAuthenticationErrors(t *testing.T) {
@@ -122,7 +168,44 @@ func TestClient_doRequest_HttpAuthenticationErrors(t *testing.T) {
 
 	_, err = c.doRequest(req)
 	require.NotNil(err)
-	assert.IsType(&AuthenticationError{}, err)
+	require.IsType(&AuthenticationError{}, err)
+
+	aerr := err.(*AuthenticationError)
+	assert.Equal(0, aerr.ErrNo)
+	assert.Equal("Can not access account. Was both Username and API Key provided?", aerr.Message)
+}
+
+func TestClient_doRequest_HttpAuthenticationErrorsWithStatusCode(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	c, err := New(Auth{Username: "random-user", Apikey: "my-pass"})
+	require.Nil(err)
+
+	hc := &sequenceHTTPClient{
+		responses: []fakeResponse{{
+			StatusCode: 401,
+			Body:       `{"ErrNo":1,"Error":"API Key is invalid"}`,
+		}},
+	}
+	c.c = hc
+
+	_, err = c.get(context.Background(), "/Tests", nil)
+	require.NotNil(err)
+	assert.Equal(&AuthenticationError{ErrNo: 1, Message: "API Key is invalid"}, err)
+}
+
+func TestParseAuthenticationError(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(&AuthenticationError{ErrNo: 0, Message: "foo"}, parseAuthenticationError([]byte(`{"ErrNo":0,"Error":"foo"}`)))
+	assert.Equal(&AuthenticationError{ErrNo: 2, Message: "Account Disabled"}, parseAuthenticationError([]byte(`{"ErrNo":2,"Error":"Account Disabled"}`)))
+	assert.Equal(&AuthenticationError{ErrNo: 3, Message: "foo"}, parseAuthenticationError([]byte(`{"ErrNo":"3","Error":"foo"}`)))
+
+	assert.Nil(parseAuthenticationError([]byte(`{"Success":false,"Error":"this is an error"}`)))
+	assert.Nil(parseAuthenticationError([]byte(`{"ErrNo":0,"Error":""}`)))
+	assert.Nil(parseAuthenticationError([]byte(`[{"TestID":1}]`)))
+	assert.Nil(parseAuthenticationError([]byte(`not json`)))
 }
 
 func TestClient_get(t *testing.T) {
@@ -135,7 +218,7 @@ func TestClient_get(t *testing.T) {
 	hc := &fakeHTTPClient{}
 	c.c = hc
 
-	c.get("/hello", nil)
+	c.get(context.Background(), "/hello", nil)
 	assert.Len(hc.requests, 1)
 	assert.Equal("GET", hc.requests[0].Method)
 	assert.Equal("https://app.statuscake.com/API/hello", hc.requests[0].URL.String())
@@ -152,7 +235,7 @@ func TestClient_put(t *testing.T) {
 	c.c = hc
 
 	v := url.Values{"foo": {"bar"}}
-	c.put("/hello", v)
+	c.put(context.Background(), "/hello", v)
 	assert.Len(hc.requests, 1)
 	assert.Equal("PUT", hc.requests[0].Method)
 	assert.Equal("https://app.statuscake.com/API/hello", hc.requests[0].URL.String())
@@ -173,7 +256,7 @@ func TestClient_delete(t *testing.T) {
 	c.c = hc
 
 	v := url.Values{"foo": {"bar"}}
-	c.delete("/hello", v)
+	c.delete(context.Background(), "/hello", v)
 	assert.Len(hc.requests, 1)
 	assert.Equal("DELETE", hc.requests[0].Method)
 	assert.Equal("https://app.statuscake.com/API/hello?foo=bar", hc.requests[0].URL.String())
@@ -193,6 +276,36 @@ func TestClient_Tests(t *testing.T) {
 	assert.Equal(expected, c.Tests())
 }
 
+func TestClient_Ssls(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	c, err := New(Auth{Username: "random-user", Apikey: "my-pass"})
+	require.Nil(err)
+
+	expected := &ssls{
+		client: c,
+	}
+
+	assert.Equal(expected, c.Ssls())
+	assert.True(c.Ssls() == c.Ssls())
+}
+
+func TestClient_ContactGroups(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	c, err := New(Auth{Username: "random-user", Apikey: "my-pass"})
+	require.Nil(err)
+
+	expected := &contactGroups{
+		client: c,
+	}
+
+	assert.Equal(expected, c.ContactGroups())
+	assert.True(c.ContactGroups() == c.ContactGroups())
+}
+
 type fakeBody struct {
 	io.Reader
 }
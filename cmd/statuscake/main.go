@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	logpkg "log"
 	"os"
@@ -16,6 +17,12 @@ type command func(*statuscake.Client, ...string) error
 
 var commands map[string]command
 
+type registryCommand func(*statuscake.Registry, ...string) error
+
+// registryCommands run across all the accounts of the credentials file,
+// read from `STATUSCAKE_CREDENTIALS_FILE` or ~/.statuscake/credentials.
+var registryCommands map[string]registryCommand
+
 func init() {
 	log = logpkg.New(os.Stderr, "", 0)
 	commands = map[string]command{
@@ -25,16 +32,19 @@ func init() {
 		"create": cmdCreate,
 		"update": cmdUpdate,
 	}
+	registryCommands = map[string]registryCommand{
+		"list-all": cmdListAll,
+	}
 }
 
-func colouredStatus(s string) string {
+func colouredStatus(s statuscake.Status) string {
 	switch s {
-	case "Up":
+	case statuscake.StatusUp:
 		return fmt.Sprintf("\033[0;32m%s\033[0m", s)
-	case "Down":
+	case statuscake.StatusDown:
 		return fmt.Sprintf("\033[0;31m%s\033[0m", s)
 	default:
-		return s
+		return string(s)
 	}
 }
 
@@ -73,6 +83,19 @@ func cmdList(c *statuscake.Client, args ...string) error {
 	return nil
 }
 
+func cmdListAll(r *statuscake.Registry, args ...string) error {
+	tests, err := r.AllTests(context.Background())
+
+	for _, t := range tests {
+		fmt.Printf("* %s/%d: %s\n", t.Account, t.TestID, colouredStatus(t.Status))
+		fmt.Printf("  WebsiteName: %s\n", t.WebsiteName)
+		fmt.Printf("  TestType: %s\n", t.TestType)
+		fmt.Printf("  Uptime: %f\n", t.Uptime)
+	}
+
+	return err
+}
+
 func cmdDetail(c *statuscake.Client, args ...string) error {
 	if len(args) != 1 {
 		return fmt.Errorf("command `detail` requires a single argument `TestID`")
@@ -157,7 +180,7 @@ func cmdCreate(c *statuscake.Client, args ...string) error {
 	t := &statuscake.Test{
 		WebsiteName:   websiteName,
 		WebsiteURL:    websiteURL,
-		TestType:      testType,
+		TestType:      statuscake.TestType(testType),
 		CheckRate:     checkRate,
 		NodeLocations: nodeLocations,
 		ContactGroup:  contactGroup,
@@ -192,7 +215,7 @@ func cmdUpdate(c *statuscake.Client, args ...string) error {
 	t.TestID = id
 	t.WebsiteName = askString(fmt.Sprintf("WebsiteName [%s]", t.WebsiteName))
 	t.WebsiteURL = askString(fmt.Sprintf("WebsiteURL [%s]", t.WebsiteURL))
-	t.TestType = askString(fmt.Sprintf("TestType [%s]", t.TestType))
+	t.TestType = statuscake.TestType(askString(fmt.Sprintf("TestType [%s]", t.TestType)))
 	t.CheckRate = askInt(fmt.Sprintf("CheckRate [%d]", t.CheckRate))
 	contactGroupString := askString("ContactGroup (comma separated list)")
 	t.ContactGroup = strings.Split(contactGroupString, ",")
@@ -216,12 +239,12 @@ func usage() {
 	for k := range commands {
 		fmt.Printf("  %+v\n", k)
 	}
+	for k := range registryCommands {
+		fmt.Printf("  %+v\n", k)
+	}
 }
 
 func main() {
-	username := getEnv("STATUSCAKE_USERNAME")
-	apikey := getEnv("STATUSCAKE_APIKEY")
-
 	if len(os.Args) < 2 {
 		usage()
 		os.Exit(1)
@@ -229,6 +252,22 @@ func main() {
 
 	var err error
 
+	if cmd, ok := registryCommands[os.Args[1]]; ok {
+		r, err := statuscake.LoadRegistry(os.Getenv("STATUSCAKE_CREDENTIALS_FILE"))
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if err = cmd(r, os.Args[2:]...); err != nil {
+			log.Fatalf("Error running command `%s`: %s", os.Args[1], err.Error())
+		}
+
+		return
+	}
+
+	username := getEnv("STATUSCAKE_USERNAME")
+	apikey := getEnv("STATUSCAKE_APIKEY")
+
 	c, err := statuscake.New(statuscake.Auth{Username: username, Apikey: apikey})
 	if err != nil {
 		log.Fatal(err)
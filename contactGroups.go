@@ -1,9 +1,11 @@
 package statuscake
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/google/go-querystring/query"
@@ -22,6 +24,9 @@ type ContactGroup struct {
 	PingURL      string   `json:"PingURL"      url:"PingURL,omitempty"`
 }
 
+// contactGroupNotFoundMessage is the error the API responds with when updating a ContactGroup that doesn't exist.
+const contactGroupNotFoundMessage = "Contact Group Not Found"
+
 //Response represent the data received from the API
 type Response struct {
 	Success  bool   `json:"Success"`
@@ -32,10 +37,15 @@ type Response struct {
 //ContactGroups represent the actions done with the API
 type ContactGroups interface {
 	All() ([]*ContactGroup, error)
+	AllContext(context.Context) ([]*ContactGroup, error)
 	Detail(int) (*ContactGroup, error)
+	DetailContext(context.Context, int) (*ContactGroup, error)
 	Update(*ContactGroup) (*ContactGroup, error)
+	UpdateContext(context.Context, *ContactGroup) (*ContactGroup, error)
 	Delete(int) error
+	DeleteContext(context.Context, int) error
 	Create(*ContactGroup) (*ContactGroup, error)
+	CreateContext(context.Context, *ContactGroup) (*ContactGroup, error)
 }
 
 func findContactGroup(responses []*ContactGroup, id int) (*ContactGroup, error) {
@@ -45,15 +55,19 @@ func findContactGroup(responses []*ContactGroup, id int) (*ContactGroup, error)
 			return elem, nil
 		}
 	}
-	return response, fmt.Errorf("%d Not found", id)
+	return response, &notFoundError{id: strconv.Itoa(id)}
 }
 
 type contactGroups struct {
 	client apiClient
 }
 
-//NewContactGroups return a new ssls
-func NewContactGroups(c apiClient) ContactGroups {
+//NewContactGroups return a new contactGroups using c to talk to the API
+func NewContactGroups(c *Client) ContactGroups {
+	return newContactGroups(c)
+}
+
+func newContactGroups(c apiClient) ContactGroups {
 	return &contactGroups{
 		client: c,
 	}
@@ -61,9 +75,14 @@ func NewContactGroups(c apiClient) ContactGroups {
 
 //All return a list of all the ContactGroup from the API
 func (tt *contactGroups) All() ([]*ContactGroup, error) {
-	rawResponse, err := tt.client.get("/ContactGroups", nil)
+	return tt.AllContext(context.Background())
+}
+
+//AllContext is like All but uses ctx for the API request
+func (tt *contactGroups) AllContext(ctx context.Context) ([]*ContactGroup, error) {
+	rawResponse, err := tt.client.get(ctx, "/ContactGroups", nil)
 	if err != nil {
-		return nil, fmt.Errorf("Error getting StatusCake contactGroups: %s", err.Error())
+		return nil, fmt.Errorf("Error getting StatusCake contactGroups: %w", err)
 	}
 	var getResponse []*ContactGroup
 	err = json.NewDecoder(rawResponse.Body).Decode(&getResponse)
@@ -75,7 +94,12 @@ func (tt *contactGroups) All() ([]*ContactGroup, error) {
 
 //Detail return the ContactGroup corresponding to the id
 func (tt *contactGroups) Detail(id int) (*ContactGroup, error) {
-	responses, err := tt.All()
+	return tt.DetailContext(context.Background(), id)
+}
+
+//DetailContext is like Detail but uses ctx for the API request
+func (tt *contactGroups) DetailContext(ctx context.Context, id int) (*ContactGroup, error) {
+	responses, err := tt.AllContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -88,18 +112,22 @@ func (tt *contactGroups) Detail(id int) (*ContactGroup, error) {
 
 //Update update the API with cg and create one if cg.ContactID=0 then return the corresponding ContactGroup
 func (tt *contactGroups) Update(cg *ContactGroup) (*ContactGroup, error) {
+	return tt.UpdateContext(context.Background(), cg)
+}
 
+//UpdateContext is like Update but uses ctx for the API request
+func (tt *contactGroups) UpdateContext(ctx context.Context, cg *ContactGroup) (*ContactGroup, error) {
 	if cg.ContactID == 0 {
-		return tt.Create(cg)
+		return tt.CreateContext(ctx, cg)
 	}
 	cg.EmailsPut = strings.Join(cg.Emails, ",")
 	var v url.Values
 
 	v, _ = query.Values(*cg)
 
-	rawResponse, err := tt.client.put("/ContactGroups/Update", v)
+	rawResponse, err := tt.client.put(ctx, "/ContactGroups/Update", v)
 	if err != nil {
-		return nil, fmt.Errorf("Error creating StatusCake ContactGroup: %s", err.Error())
+		return nil, fmt.Errorf("Error creating StatusCake ContactGroup: %w", err)
 	}
 
 	var response Response
@@ -109,7 +137,7 @@ func (tt *contactGroups) Update(cg *ContactGroup) (*ContactGroup, error) {
 	}
 
 	if !response.Success {
-		return nil, fmt.Errorf("%s", response.Message)
+		return nil, newContactGroupUpdateError(response.Message)
 	}
 
 	return cg, nil
@@ -117,20 +145,30 @@ func (tt *contactGroups) Update(cg *ContactGroup) (*ContactGroup, error) {
 
 //Delete delete the ContactGroup which ID is id
 func (tt *contactGroups) Delete(id int) error {
-	_, err := tt.client.delete("/ContactGroups/Update", url.Values{"ContactID": {fmt.Sprint(id)}})
+	return tt.DeleteContext(context.Background(), id)
+}
+
+//DeleteContext is like Delete but uses ctx for the API request
+func (tt *contactGroups) DeleteContext(ctx context.Context, id int) error {
+	_, err := tt.client.delete(ctx, "/ContactGroups/Update", url.Values{"ContactID": {fmt.Sprint(id)}})
 	return err
 }
 
 //CreatePartial create the ContactGroup with the data in cg and return the ContactGroup created
 func (tt *contactGroups) Create(cg *ContactGroup) (*ContactGroup, error) {
+	return tt.CreateContext(context.Background(), cg)
+}
+
+//CreateContext is like Create but uses ctx for the API request
+func (tt *contactGroups) CreateContext(ctx context.Context, cg *ContactGroup) (*ContactGroup, error) {
 	cg.ContactID = 0
 	cg.EmailsPut = strings.Join(cg.Emails, ",")
 	var v url.Values
 	v, _ = query.Values(*cg)
 
-	rawResponse, err := tt.client.put("/ContactGroups/Update", v)
+	rawResponse, err := tt.client.put(withoutRetry(ctx), "/ContactGroups/Update", v)
 	if err != nil {
-		return nil, fmt.Errorf("Error creating StatusCake ContactGroup: %s", err.Error())
+		return nil, fmt.Errorf("Error creating StatusCake ContactGroup: %w", err)
 	}
 
 	var response Response
@@ -140,10 +178,17 @@ func (tt *contactGroups) Create(cg *ContactGroup) (*ContactGroup, error) {
 	}
 
 	if !response.Success {
-		return nil, fmt.Errorf("%s", response.Message)
+		return nil, newUpdateError(response.Message, nil)
 	}
 
 	cg.ContactID = response.InsertID
 
 	return cg, nil
 }
+
+func newContactGroupUpdateError(message string) *UpdateError {
+	e := newUpdateError(message, nil)
+	e.notFound = strings.EqualFold(message, contactGroupNotFoundMessage)
+
+	return e
+}
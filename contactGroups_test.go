@@ -14,7 +14,7 @@ func TestContactGroup_All(t *testing.T) {
 	c := &fakeAPIClient{
 		fixture: "contactGroupListAllOk.json",
 	}
-	tt := NewContactGroups(c)
+	tt := newContactGroups(c)
 	contactGroups, err := tt.All()
 	require.Nil(err)
 
@@ -49,7 +49,7 @@ func TestContactGroup_Detail(t *testing.T) {
 	c := &fakeAPIClient{
 		fixture: "contactGroupListAllOk.json",
 	}
-	tt := NewContactGroups(c)
+	tt := newContactGroups(c)
 
 	contactGroup, err := tt.Detail(123456)
 	require.Nil(err)
@@ -77,7 +77,7 @@ func TestContactGroup_Create(t *testing.T) {
 	c := &fakeAPIClient{
 		fixture: "contactGroupCreateOk.json",
 	}
-	tt := NewContactGroups(c)
+	tt := newContactGroups(c)
 	contactGroup := &ContactGroup{
 		GroupName:      "group name",
 		Emails:         []string{"aaaaaa","bbbbbb"},
@@ -99,7 +99,7 @@ func TestContactGroup_Update(t *testing.T) {
 	c := &fakeAPIClient{
 		fixture: "contactGroupUpdateOk.json",
 	}
-	tt := NewContactGroups(c)
+	tt := newContactGroups(c)
 
 	contactGroup := &ContactGroup{
 		GroupName:      "group name",
@@ -123,7 +123,7 @@ func TestContactGroup_Delete(t *testing.T) {
 	c := &fakeAPIClient{
 		fixture: "contactGroupDeleteOk.json",
 	}
-	tt := NewContactGroups(c)
+	tt := newContactGroups(c)
 
 	err := tt.Delete(12345)
 	require.Nil(err)
@@ -0,0 +1,252 @@
+package statuscake
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// EnvUsername is the environment variable read by EnvCredentials for the username.
+	EnvUsername = "STATUSCAKE_USERNAME"
+
+	// EnvAPIKey is the environment variable read by EnvCredentials for the API key.
+	EnvAPIKey = "STATUSCAKE_APIKEY"
+
+	// DefaultProfile is the profile read by FileCredentials when none is specified.
+	DefaultProfile = "default"
+)
+
+// CredentialsProvider provides the credentials used to authenticate the requests.
+// It's consulted every time a request is sent.
+type CredentialsProvider interface {
+	Credentials(context.Context) (Auth, error)
+}
+
+// WithCredentials makes the Client use p to authenticate the requests.
+// The Auth passed to New is ignored when this option is used.
+func WithCredentials(p CredentialsProvider) Option {
+	return func(c *Client) {
+		c.creds = p
+	}
+}
+
+// StaticCredentials is a CredentialsProvider that always returns the same credentials.
+type StaticCredentials Auth
+
+// Credentials implements CredentialsProvider.
+func (s StaticCredentials) Credentials(context.Context) (Auth, error) {
+	auth := Auth(s)
+	return auth, auth.validate()
+}
+
+// EnvCredentials is a CredentialsProvider reading the credentials from the
+// STATUSCAKE_USERNAME and STATUSCAKE_APIKEY environment variables.
+type EnvCredentials struct{}
+
+// Credentials implements CredentialsProvider.
+func (EnvCredentials) Credentials(context.Context) (Auth, error) {
+	auth := Auth{
+		Username: os.Getenv(EnvUsername),
+		Apikey:   os.Getenv(EnvAPIKey),
+	}
+
+	return auth, auth.validate()
+}
+
+// FileCredentials is a CredentialsProvider reading the credentials from a file with profile sections:
+//
+//	[default]
+//	username = foo
+//	apikey = bar
+//
+//	[staging]
+//	username = baz
+//	apikey = qux
+type FileCredentials struct {
+	// Path is the path of the credentials file. It defaults to ~/.statuscake/credentials.
+	Path string
+
+	// Profile is the section of the file to read. It defaults to "default".
+	Profile string
+}
+
+// Credentials implements CredentialsProvider.
+func (f FileCredentials) Credentials(context.Context) (Auth, error) {
+	path := f.Path
+	if path == "" {
+		path = defaultCredentialsPath()
+	}
+
+	profile := f.Profile
+	if profile == "" {
+		profile = DefaultProfile
+	}
+
+	profiles, err := readCredentialsFile(path)
+	if err != nil {
+		return Auth{}, err
+	}
+
+	auth, ok := profiles[profile]
+	if !ok {
+		return Auth{}, fmt.Errorf("profile `%s` not found in %s", profile, path)
+	}
+
+	return auth, auth.validate()
+}
+
+// defaultCredentialsPath returns ~/.statuscake/credentials.
+func defaultCredentialsPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		// let the caller fail when opening the file
+		return filepath.Join(".statuscake", "credentials")
+	}
+
+	return filepath.Join(home, ".statuscake", "credentials")
+}
+
+// readCredentialsFile returns the credentials of each profile of the file at path.
+func readCredentialsFile(path string) (map[string]Auth, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	profiles := make(map[string]Auth)
+	var profile string
+
+	s := bufio.NewScanner(f)
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			profile = strings.TrimSpace(line[1 : len(line)-1])
+			profiles[profile] = Auth{}
+			continue
+		}
+
+		kv := strings.SplitN(line, "=", 2)
+		if len(kv) != 2 || profile == "" {
+			return nil, fmt.Errorf("%s:%d: invalid line", path, n)
+		}
+
+		auth := profiles[profile]
+		switch strings.ToLower(strings.TrimSpace(kv[0])) {
+		case "username":
+			auth.Username = strings.TrimSpace(kv[1])
+		case "apikey":
+			auth.Apikey = strings.TrimSpace(kv[1])
+		}
+		profiles[profile] = auth
+	}
+
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	return profiles, nil
+}
+
+// CachedCredentials is a CredentialsProvider caching the credentials of another provider.
+// The Client invalidates the cache when the API rejects the credentials
+// (see AuthenticationError.InvalidCredentials), so that rotated credentials are picked up.
+type CachedCredentials struct {
+	provider CredentialsProvider
+	ttl      time.Duration
+
+	mu      sync.Mutex
+	auth    Auth
+	expires time.Time
+	valid   bool
+}
+
+// NewCachedCredentials returns a CachedCredentials caching the credentials of p for ttl.
+// A ttl of 0 caches the credentials until they are invalidated.
+func NewCachedCredentials(p CredentialsProvider, ttl time.Duration) *CachedCredentials {
+	return &CachedCredentials{
+		provider: p,
+		ttl:      ttl,
+	}
+}
+
+// Credentials implements CredentialsProvider.
+func (c *CachedCredentials) Credentials(ctx context.Context) (Auth, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.valid && (c.ttl == 0 || time.Now().Before(c.expires)) {
+		return c.auth, nil
+	}
+
+	auth, err := c.provider.Credentials(ctx)
+	if err != nil {
+		return Auth{}, err
+	}
+
+	c.auth = auth
+	c.expires = time.Now().Add(c.ttl)
+	c.valid = true
+
+	return auth, nil
+}
+
+// Invalidate clears the cache, the next call to Credentials consults the underlying provider.
+func (c *CachedCredentials) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.valid = false
+}
+
+// invalidator is implemented by the providers that can be refreshed after an AuthenticationError.
+type invalidator interface {
+	Invalidate()
+}
+
+// authenticate sets the credentials headers of r.
+func (c *Client) authenticate(r *http.Request) error {
+	auth, err := c.creds.Credentials(r.Context())
+	if err != nil {
+		return err
+	}
+
+	r.Header.Set("Username", auth.Username)
+	r.Header.Set("API", auth.Apikey)
+
+	return nil
+}
+
+// reauthenticate invalidates the cached credentials after an AuthenticationError and returns
+// a copy of r with fresh credentials. It returns false if the credentials didn't change.
+func (c *Client) reauthenticate(r *http.Request) (*http.Request, bool) {
+	inv, ok := c.creds.(invalidator)
+	if !ok {
+		return nil, false
+	}
+	inv.Invalidate()
+
+	r2, err := rewindRequest(r)
+	if err != nil {
+		return nil, false
+	}
+
+	if err := c.authenticate(r2); err != nil {
+		return nil, false
+	}
+
+	changed := r2.Header.Get("Username") != r.Header.Get("Username") || r2.Header.Get("API") != r.Header.Get("API")
+
+	return r2, changed
+}
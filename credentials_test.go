@@ -0,0 +1,210 @@
+package statuscake
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaticCredentials(t *testing.T) {
+	assert := assert.New(t)
+
+	auth, err := StaticCredentials{Username: "random-user", Apikey: "my-pass"}.Credentials(context.Background())
+	assert.Nil(err)
+	assert.Equal(Auth{Username: "random-user", Apikey: "my-pass"}, auth)
+
+	_, err = StaticCredentials{}.Credentials(context.Background())
+	assert.IsType(ValidationError{}, err)
+}
+
+func TestEnvCredentials(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Setenv(EnvUsername, "env-user")
+	t.Setenv(EnvAPIKey, "env-pass")
+
+	auth, err := EnvCredentials{}.Credentials(context.Background())
+	assert.Nil(err)
+	assert.Equal(Auth{Username: "env-user", Apikey: "env-pass"}, auth)
+
+	t.Setenv(EnvAPIKey, "")
+
+	_, err = EnvCredentials{}.Credentials(context.Background())
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "Apikey is required")
+}
+
+func TestFileCredentials(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	p := filepath.Join(t.TempDir(), "credentials")
+	err := ioutil.WriteFile(p, []byte(`
+# StatusCake accounts
+[default]
+username = prod-user
+apikey = prod-pass
+
+[staging]
+Username=staging-user
+ApiKey=staging-pass
+`), 0600)
+	require.Nil(err)
+
+	auth, err := FileCredentials{Path: p}.Credentials(context.Background())
+	assert.Nil(err)
+	assert.Equal(Auth{Username: "prod-user", Apikey: "prod-pass"}, auth)
+
+	auth, err = FileCredentials{Path: p, Profile: "staging"}.Credentials(context.Background())
+	assert.Nil(err)
+	assert.Equal(Auth{Username: "staging-user", Apikey: "staging-pass"}, auth)
+
+	_, err = FileCredentials{Path: p, Profile: "missing"}.Credentials(context.Background())
+	require.NotNil(err)
+	assert.Contains(err.Error(), "profile `missing` not found")
+
+	_, err = FileCredentials{Path: filepath.Join(t.TempDir(), "nope")}.Credentials(context.Background())
+	assert.NotNil(err)
+}
+
+type countingCredentials struct {
+	calls int
+	auths []Auth
+}
+
+func (c *countingCredentials) Credentials(context.Context) (Auth, error) {
+	i := c.calls
+	if i >= len(c.auths) {
+		i = len(c.auths) - 1
+	}
+	c.calls++
+
+	return c.auths[i], nil
+}
+
+func TestCachedCredentials(t *testing.T) {
+	assert := assert.New(t)
+
+	p := &countingCredentials{auths: []Auth{{Username: "a", Apikey: "1"}, {Username: "a", Apikey: "2"}}}
+	cc := NewCachedCredentials(p, 0)
+
+	auth, _ := cc.Credentials(context.Background())
+	assert.Equal("1", auth.Apikey)
+	auth, _ = cc.Credentials(context.Background())
+	assert.Equal("1", auth.Apikey)
+	assert.Equal(1, p.calls)
+
+	cc.Invalidate()
+	auth, _ = cc.Credentials(context.Background())
+	assert.Equal("2", auth.Apikey)
+	assert.Equal(2, p.calls)
+
+	cc = NewCachedCredentials(p, time.Nanosecond)
+	cc.Credentials(context.Background())
+	time.Sleep(time.Millisecond)
+	cc.Credentials(context.Background())
+	assert.Equal(4, p.calls)
+}
+
+func TestClient_WithCredentials(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	p := &countingCredentials{auths: []Auth{{Username: "a", Apikey: "1"}, {Username: "a", Apikey: "2"}}}
+	c, err := New(Auth{}, WithCredentials(p))
+	require.Nil(err)
+
+	r, err := c.newRequest(context.Background(), "GET", "/hello", nil, nil)
+	require.Nil(err)
+	assert.Equal("1", r.Header.Get("API"))
+
+	r, err = c.newRequest(context.Background(), "GET", "/hello", nil, nil)
+	require.Nil(err)
+	assert.Equal("2", r.Header.Get("API"))
+}
+
+func TestClient_doRequest_RefreshesCredentials(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	p := &countingCredentials{auths: []Auth{{Username: "a", Apikey: "old"}, {Username: "a", Apikey: "new"}}}
+	c, err := New(Auth{}, WithCredentials(NewCachedCredentials(p, 0)))
+	require.Nil(err)
+
+	hc := &sequenceHTTPClient{
+		responses: []fakeResponse{
+			{StatusCode: 200, Body: `{"ErrNo":0,"Error":"Authentication Error"}`},
+			{StatusCode: 200, Body: `[]`},
+		},
+	}
+	c.c = hc
+
+	_, err = c.Tests().All()
+	require.Nil(err)
+
+	require.Len(hc.requests, 2)
+	assert.Equal("old", hc.requests[0].Header.Get("API"))
+	assert.Equal("new", hc.requests[1].Header.Get("API"))
+}
+
+func TestClient_doRequest_DoesNotRefreshUnchangedCredentials(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	c, err := New(Auth{}, WithCredentials(NewCachedCredentials(StaticCredentials{Username: "a", Apikey: "1"}, 0)))
+	require.Nil(err)
+
+	hc := &sequenceHTTPClient{
+		responses: []fakeResponse{
+			{StatusCode: 200, Body: `{"ErrNo":0,"Error":"Authentication Error"}`},
+		},
+	}
+	c.c = hc
+
+	_, err = c.Tests().All()
+	assert.IsType(&AuthenticationError{}, err)
+	assert.Len(hc.requests, 1)
+}
+
+func TestClient_doRequest_ReauthenticatesOnlyOnInvalidCredentials(t *testing.T) {
+	tests := []struct {
+		errNo  int
+		resent bool
+	}{
+		{ErrNoMissingCredentials, true},
+		{ErrNoInvalidKey, true},
+		{ErrNoAccountDisabled, false},
+		{3, false},
+	}
+
+	for _, tt := range tests {
+		p := &countingCredentials{auths: []Auth{{Username: "a", Apikey: "old"}, {Username: "a", Apikey: "new"}}}
+		c, err := New(Auth{}, WithCredentials(NewCachedCredentials(p, 0)))
+		require.Nil(t, err)
+
+		hc := &sequenceHTTPClient{
+			responses: []fakeResponse{
+				{StatusCode: 200, Body: fmt.Sprintf(`{"ErrNo":%d,"Error":"foo"}`, tt.errNo)},
+				{StatusCode: 200, Body: `[]`},
+			},
+		}
+		c.c = hc
+
+		_, err = c.Tests().All()
+		if tt.resent {
+			assert.Nil(t, err, "ErrNo %d", tt.errNo)
+			assert.Len(t, hc.requests, 2, "ErrNo %d", tt.errNo)
+			assert.Equal(t, 2, p.calls, "ErrNo %d", tt.errNo)
+		} else {
+			assert.Equal(t, &AuthenticationError{ErrNo: tt.errNo, Message: "foo"}, err, "ErrNo %d", tt.errNo)
+			assert.Len(t, hc.requests, 1, "ErrNo %d", tt.errNo)
+			assert.Equal(t, 1, p.calls, "ErrNo %d", tt.errNo)
+		}
+	}
+}
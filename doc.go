@@ -11,9 +11,10 @@
 //    log.Fatal(err)
 //  }
 //
-//  v := url.Values{}
-//  v.Set("tags", "test1,test2")
-//  testsWithFilter, err := c.Tests().AllWithFilter(v)
+//  testsWithFilter, err := c.Tests().AllMatching(&statuscake.TestFilter{
+//    Tags:   []string{"test1", "test2"},
+//    Status: statuscake.StatusDown,
+//  })
 //  if err != nil {
 //    log.Fatal(err)
 //  }
@@ -41,4 +42,35 @@
 //  // get Tests details
 //  t, err := tt.Detail(id)
 //  ...
+//
+//  // only change some settings of a Test, leaving the others untouched
+//  err = c.Tests().Patch(id, &statuscake.TestPatch{
+//    CheckRate:      statuscake.Ptr(60),
+//    FollowRedirect: statuscake.Ptr(false),
+//  })
+//
+//  // pause the Tests tagged "web" during a deploy, then resume them
+//  results, err := c.Tests().PauseWithTags([]string{"web"})
+//  ...
+//  results, err = c.Tests().ResumeWithTags([]string{"web"})
+//
+//  // get the up and down periods of a Test
+//  periods, err := c.Tests().Periods(id)
+//
+//  // get the Alerts sent for a Test during the last week
+//  alerts, err := c.Tests().Alerts(id, time.Now().AddDate(0, 0, -7))
+//
+//  // get the response times of a Test during the last day, from one location
+//  checks, err := c.Tests().Checks(id, &statuscake.CheckFilter{
+//    Start:    time.Now().AddDate(0, 0, -1),
+//    Location: "UK1",
+//  })
+//
+//  // every method has a Context variant to cancel slow requests
+//  ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+//  defer cancel()
+//  tests, err = c.Tests().AllContext(ctx)
+//
+//  // the client can be configured with options
+//  c, err = statuscake.New(auth, statuscake.WithTimeout(30*time.Second), statuscake.WithUserAgent("my-tool/1.0"))
 package statuscake
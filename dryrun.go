@@ -0,0 +1,118 @@
+package statuscake
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// WithReadOnly makes the Client refuse every request that could change something:
+// they return a ReadOnlyError without being sent.
+func WithReadOnly() Option {
+	return func(c *Client) {
+		c.readOnly = true
+	}
+}
+
+// WithDryRun makes the Client record the requests that would change something instead of sending them,
+// and return a successful response. The recorded requests are returned by DryRunRequests.
+// Requests reading data are sent as usual.
+func WithDryRun() Option {
+	return func(c *Client) {
+		c.dryRun = true
+	}
+}
+
+// ReadOnlyError implements the error interface and it's returned by read-only Clients
+// for the requests that could change something.
+type ReadOnlyError struct {
+	Method string
+	Path   string
+}
+
+func (e *ReadOnlyError) Error() string {
+	return fmt.Sprintf("read-only client: %s %s not sent", e.Method, e.Path)
+}
+
+// Is returns true if target is ErrReadOnly.
+func (e *ReadOnlyError) Is(target error) bool {
+	return target == ErrReadOnly
+}
+
+// DryRunRequest is a request recorded by a Client in dry-run mode.
+type DryRunRequest struct {
+	Method string
+	Path   string
+	Values url.Values
+}
+
+// DryRunRequests returns the requests recorded by a Client in dry-run mode, in the order they were made.
+func (c *Client) DryRunRequests() []DryRunRequest {
+	c.dryRunMu.Lock()
+	defer c.dryRunMu.Unlock()
+
+	return append([]DryRunRequest(nil), c.dryRunRequests...)
+}
+
+// checkWrite returns a ReadOnlyError if the Client is read-only,
+// or a synthetic response if the Client is in dry-run mode.
+// It returns nil, nil if the request must be sent.
+func (c *Client) checkWrite(method string, path string, v url.Values) (*http.Response, error) {
+	if c.readOnly {
+		return nil, &ReadOnlyError{Method: method, Path: path}
+	}
+
+	if !c.dryRun {
+		return nil, nil
+	}
+
+	c.dryRunMu.Lock()
+	c.dryRunRequests = append(c.dryRunRequests, DryRunRequest{
+		Method: method,
+		Path:   path,
+		Values: RedactValues(v),
+	})
+	c.dryRunMu.Unlock()
+
+	b, err := json.Marshal(dryRunResponse(method, path, v))
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Header:     make(http.Header),
+		Body:       &responseBody{Reader: bytes.NewReader(b)},
+	}, nil
+}
+
+// dryRunResponse returns a successful response matching the one the API would return for the request.
+func dryRunResponse(method string, path string, v url.Values) interface{} {
+	if method == "PUT" && path == "/SSL/Update" && v.Get("id") == "" {
+		cs := createSsl{
+			Domain:         v.Get("domain"),
+			Checkrate:      jsonNumberString(v.Get("checkrate")),
+			ContactGroupsC: v.Get("contact_groups"),
+			AlertAt:        v.Get("alert_at"),
+		}
+		cs.AlertExpiry, _ = strconv.ParseBool(v.Get("alert_expiry"))
+		cs.AlertReminder, _ = strconv.ParseBool(v.Get("alert_reminder"))
+		cs.AlertBroken, _ = strconv.ParseBool(v.Get("alert_broken"))
+		cs.AlertMixed, _ = strconv.ParseBool(v.Get("alert_mixed"))
+
+		// the API returns the ID of the new Ssl as Message
+		return sslCreateResponse{Success: true, Message: 0, Input: cs}
+	}
+
+	id, _ := strconv.Atoi(v.Get("TestID"))
+
+	return updateResponse{
+		Success:  true,
+		Message:  "dry run",
+		InsertID: id,
+	}
+}
@@ -0,0 +1,143 @@
+package statuscake
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_WithReadOnly(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	c, err := New(Auth{Username: "random-user", Apikey: "my-pass"}, WithReadOnly())
+	require.Nil(err)
+
+	hc := &fakeHTTPClient{StatusCode: 200, Fixture: "tests_all_ok.json"}
+	c.c = hc
+
+	_, err = c.Tests().Update(&Test{TestID: 1234, WebsiteName: "foo"})
+	require.NotNil(err)
+	assert.Equal(&ReadOnlyError{Method: "PUT", Path: "/Tests/Update"}, err)
+	assert.True(errors.Is(err, ErrReadOnly))
+
+	err = c.Tests().Delete(1234)
+	assert.True(errors.Is(err, ErrReadOnly))
+
+	err = c.Ssls().Delete("1234")
+	assert.True(errors.Is(err, ErrReadOnly))
+
+	err = c.ContactGroups().Delete(1234)
+	assert.True(errors.Is(err, ErrReadOnly))
+
+	assert.Empty(hc.requests)
+
+	tests, err := c.Tests().All()
+	require.Nil(err)
+	assert.Len(tests, 2)
+	assert.Len(hc.requests, 1)
+}
+
+func TestClient_WithDryRun_Tests(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	c, err := New(Auth{Username: "random-user", Apikey: "my-pass"}, WithDryRun())
+	require.Nil(err)
+
+	hc := &fakeHTTPClient{StatusCode: 200}
+	c.c = hc
+
+	test, err := c.Tests().Update(&Test{TestID: 1234, WebsiteName: "foo", BasicPass: "secret"})
+	require.Nil(err)
+	assert.Equal(1234, test.TestID)
+	assert.Equal("foo", test.WebsiteName)
+
+	err = c.Tests().Delete(1234)
+	require.Nil(err)
+
+	assert.Empty(hc.requests)
+
+	requests := c.DryRunRequests()
+	require.Len(requests, 2)
+	assert.Equal("PUT", requests[0].Method)
+	assert.Equal("/Tests/Update", requests[0].Path)
+	assert.Equal("foo", requests[0].Values.Get("WebsiteName"))
+	assert.Equal("REDACTED", requests[0].Values.Get("BasicPass"))
+	assert.Equal(DryRunRequest{Method: "DELETE", Path: "/Tests/Details", Values: url.Values{"TestID": {"1234"}}}, requests[1])
+}
+
+func TestClient_WithDryRun_Ssls(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	c, err := New(Auth{Username: "random-user", Apikey: "my-pass"}, WithDryRun())
+	require.Nil(err)
+
+	hc := &fakeHTTPClient{StatusCode: 200, Fixture: "sslListAllOk.json"}
+	c.c = hc
+
+	ssl, err := c.Ssls().Create(&PartialSsl{
+		Domain:         "https://www.example.com",
+		Checkrate:      "3600",
+		ContactGroupsC: "12,13",
+		AlertAt:        "7,18,2019",
+		AlertExpiry:    true,
+	})
+	require.Nil(err)
+	assert.Equal("https://www.example.com", ssl.Domain)
+	assert.Equal(3600, ssl.Checkrate)
+	assert.Equal([]string{"12", "13"}, ssl.ContactGroups)
+	assert.True(ssl.AlertExpiry)
+	assert.Empty(hc.requests)
+
+	ssl, err = c.Ssls().Update(&PartialSsl{ID: 143616, Domain: "https://www.exemple.com", Checkrate: "2073600", ContactGroupsC: "12"})
+	require.Nil(err)
+	assert.Equal("143616", ssl.ID)
+	assert.Equal("https://www.exemple.com", ssl.Domain)
+	assert.Equal(2073600, ssl.Checkrate)
+	assert.Equal([]string{"12"}, ssl.ContactGroups)
+	assert.Empty(hc.requests)
+
+	require.Nil(c.Ssls().Delete("143616"))
+
+	requests := c.DryRunRequests()
+	require.Len(requests, 3)
+	assert.Equal("PUT", requests[0].Method)
+	assert.Equal("/SSL/Update", requests[0].Path)
+	assert.Equal("143616", requests[1].Values.Get("id"))
+	assert.Equal("DELETE", requests[2].Method)
+}
+
+func TestClient_WithDryRun_ContactGroups(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	c, err := New(Auth{Username: "random-user", Apikey: "my-pass"}, WithDryRun())
+	require.Nil(err)
+
+	hc := &fakeHTTPClient{StatusCode: 200}
+	c.c = hc
+
+	cg, err := c.ContactGroups().Create(&ContactGroup{GroupName: "group name", Emails: []string{"a@example.com"}})
+	require.Nil(err)
+	assert.Equal("group name", cg.GroupName)
+
+	_, err = c.ContactGroups().Update(&ContactGroup{ContactID: 1234, GroupName: "group name"})
+	require.Nil(err)
+
+	require.Nil(c.ContactGroups().Delete(1234))
+
+	assert.Empty(hc.requests)
+	assert.Len(c.DryRunRequests(), 3)
+}
+
+func TestDryRunResponse(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(updateResponse{Success: true, Message: "dry run", InsertID: 1234}, dryRunResponse("PUT", "/Tests/Update", url.Values{"TestID": {"1234"}}))
+	assert.Equal(updateResponse{Success: true, Message: "dry run"}, dryRunResponse("DELETE", "/SSL/Update", url.Values{"id": {"1234"}}))
+}
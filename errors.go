@@ -1,22 +1,83 @@
 package statuscake
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
+	"sort"
 	"strings"
 )
 
+var (
+	// ErrNotFound is matched by errors returned when the requested resource doesn't exist.
+	ErrNotFound = errors.New("not found")
+
+	// ErrUnauthorized is matched by errors returned when the API rejects the credentials.
+	ErrUnauthorized = errors.New("unauthorized")
+
+	// ErrRateLimited is matched by errors returned when the API throttles the requests.
+	ErrRateLimited = errors.New("rate limited")
+
+	// ErrValidation is matched by errors returned when a resource is invalid,
+	// either because of client side validation or because the API rejected it.
+	ErrValidation = errors.New("validation failed")
+
+	// ErrReadOnly is matched by errors returned when a read-only Client is asked to change something.
+	ErrReadOnly = errors.New("read-only client")
+)
+
 // APIError implements the error interface an it's used when the API response has errors.
 type APIError interface {
 	APIError() string
 }
 
-type httpError struct {
-	status     string
-	statusCode int
+// maxHTTPErrorBody is the maximum number of bytes of the response body kept in an HTTPError.
+const maxHTTPErrorBody = 1024
+
+// HTTPError implements the error interface and it's returned when the API responds with a non 2xx status code.
+type HTTPError struct {
+	// StatusCode is the HTTP status code of the response, e.g. 401.
+	StatusCode int
+
+	// Status is the HTTP status of the response, e.g. "401 Unauthorized".
+	Status string
+
+	// Header contains the headers of the response.
+	Header http.Header
+
+	// Method is the HTTP method of the request.
+	Method string
+
+	// Path is the URL path of the request.
+	Path string
+
+	// Body is the response body, truncated to 1024 bytes.
+	Body string
+}
+
+func (e *HTTPError) Error() string {
+	m := fmt.Sprintf("HTTP error: %d - %s", e.StatusCode, e.Status)
+	if e.Body != "" {
+		m = fmt.Sprintf("%s: %s", m, e.Body)
+	}
+
+	return m
 }
 
-func (e *httpError) Error() string {
-	return fmt.Sprintf("HTTP error: %d - %s", e.statusCode, e.status)
+// Is reports whether the status code of e matches one of the sentinel errors.
+func (e *HTTPError) Is(target error) bool {
+	switch e.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return target == ErrUnauthorized
+	case http.StatusNotFound:
+		return target == ErrNotFound
+	case http.StatusTooManyRequests:
+		return target == ErrRateLimited
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return target == ErrValidation
+	}
+
+	return false
 }
 
 // ValidationError is a map where the key is the invalid field and the value is a message describing why the field is invalid.
@@ -33,39 +94,102 @@ func (e ValidationError) Error() string {
 	return strings.Join(messages, ", ")
 }
 
-type updateError struct {
-	Issues  interface{}
+// Is returns true if target is ErrValidation.
+func (e ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// UpdateIssue describes a problem reported by the API when rejecting an update.
+type UpdateIssue struct {
+	// Field is the name of the rejected field. It's empty when the API doesn't specify it.
+	Field string
+
+	// Message describes why the field was rejected.
 	Message string
 }
 
-func (e *updateError) Error() string {
+// UpdateError implements the error interface and it's returned when the API rejects an update.
+type UpdateError struct {
+	// Message is the error message returned by the API.
+	Message string
+
+	// Issues lists the problems reported by the API, ordered by field name
+	// when the API reports them by field, in the original order otherwise.
+	Issues []UpdateIssue
+
+	// notFound is set when the API rejected the update because the resource doesn't exist.
+	notFound bool
+}
+
+func newUpdateError(message string, issues interface{}) *UpdateError {
+	e := &UpdateError{Message: message}
+
+	switch v := issues.(type) {
+	case nil:
+	case map[string]interface{}:
+		fields := make([]string, 0, len(v))
+		for k := range v {
+			fields = append(fields, k)
+		}
+		sort.Strings(fields)
+
+		for _, k := range fields {
+			e.Issues = append(e.Issues, UpdateIssue{Field: k, Message: fmt.Sprint(v[k])})
+		}
+	case []interface{}:
+		for _, m := range v {
+			e.Issues = append(e.Issues, UpdateIssue{Message: fmt.Sprint(m)})
+		}
+	default:
+		e.Issues = append(e.Issues, UpdateIssue{Message: fmt.Sprint(v)})
+	}
+
+	return e
+}
+
+func (e *UpdateError) Error() string {
 	var messages []string
 
 	messages = append(messages, e.Message)
 
-	if issues, ok := e.Issues.(map[string]interface{}); ok {
-		for k, v := range issues {
-			m := fmt.Sprintf("%s %s", k, v)
-			messages = append(messages, m)
-		}
-	} else if issues, ok := e.Issues.([]interface{}); ok {
-		for _, v := range issues {
-			m := fmt.Sprint(v)
-			messages = append(messages, m)
+	for _, issue := range e.Issues {
+		if issue.Field != "" {
+			messages = append(messages, fmt.Sprintf("%s %s", issue.Field, issue.Message))
+		} else {
+			messages = append(messages, issue.Message)
 		}
-	} else if issue, ok := e.Issues.(interface{}); ok {
-		m := fmt.Sprint(issue)
-		messages = append(messages, m)
 	}
 
 	return strings.Join(messages, ", ")
 }
 
 // APIError returns the error specified in the API response
-func (e *updateError) APIError() string {
+func (e *UpdateError) APIError() string {
 	return e.Error()
 }
 
+// Is returns true if target is ErrNotFound and the API reported a missing resource,
+// or if target is ErrValidation otherwise.
+func (e *UpdateError) Is(target error) bool {
+	if e.notFound {
+		return target == ErrNotFound
+	}
+
+	return target == ErrValidation
+}
+
+// Fields returns the issues reported for a specific field as a ValidationError.
+func (e *UpdateError) Fields() ValidationError {
+	fields := make(ValidationError)
+	for _, issue := range e.Issues {
+		if issue.Field != "" {
+			fields[issue.Field] = issue.Message
+		}
+	}
+
+	return fields
+}
+
 type deleteError struct {
 	Message string
 }
@@ -74,13 +198,78 @@ func (e *deleteError) Error() string {
 	return e.Message
 }
 
+// Is returns true if target is ErrNotFound and the API reported a missing test.
+// A missing test is otherwise reported with a 404 HTTPError.
+func (e *deleteError) Is(target error) bool {
+	return target == ErrNotFound && strings.EqualFold(e.Message, deleteNotFoundMessage)
+}
+
+// deleteNotFoundMessage is the error the API responds with, alongside a 200 status code
+// and no error number, when deleting a test that doesn't exist.
+const deleteNotFoundMessage = "Test Not Found"
+
+type notFoundError struct {
+	id string
+}
+
+func (e *notFoundError) Error() string {
+	return fmt.Sprintf("%s Not found", e.id)
+}
+
+func (e *notFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// Error numbers returned by the API in authentication error responses.
+const (
+	// ErrNoMissingCredentials is returned when the Username or the API key is missing or unknown.
+	ErrNoMissingCredentials = 0
+
+	// ErrNoInvalidKey is returned when the API key doesn't match the Username.
+	ErrNoInvalidKey = 1
+
+	// ErrNoAccountDisabled is returned when the account is disabled.
+	ErrNoAccountDisabled = 2
+)
+
 // AuthenticationError implements the error interface and it's returned
 // when API responses have authentication errors
 type AuthenticationError struct {
-	errNo   int
-	message string
+	// ErrNo is the error number returned by the API.
+	ErrNo int
+
+	// Message is the error message returned by the API, e.g. "Authentication Error".
+	Message string
 }
 
 func (e *AuthenticationError) Error() string {
-	return fmt.Sprintf("%d, %s", e.errNo, e.message)
+	return fmt.Sprintf("%d, %s", e.ErrNo, e.Message)
+}
+
+// Is returns true if target is ErrUnauthorized.
+func (e *AuthenticationError) Is(target error) bool {
+	return target == ErrUnauthorized
+}
+
+// InvalidCredentials returns true if the API rejected the Username or the API key,
+// i.e. if fresh credentials may succeed.
+func (e *AuthenticationError) InvalidCredentials() bool {
+	return e.ErrNo == ErrNoMissingCredentials || e.ErrNo == ErrNoInvalidKey
+}
+
+// AccountDisabled returns true if the API rejected the request because the account is disabled.
+func (e *AuthenticationError) AccountDisabled() bool {
+	return e.ErrNo == ErrNoAccountDisabled
+}
+
+// IsInvalidKey returns true if err is an AuthenticationError for missing or invalid credentials.
+func IsInvalidKey(err error) bool {
+	var aerr *AuthenticationError
+	return errors.As(err, &aerr) && aerr.InvalidCredentials()
+}
+
+// IsAccountDisabled returns true if err is an AuthenticationError for a disabled account.
+func IsAccountDisabled(err error) bool {
+	var aerr *AuthenticationError
+	return errors.As(err, &aerr) && aerr.AccountDisabled()
 }
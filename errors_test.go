@@ -0,0 +1,157 @@
+package statuscake
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPError_Is(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(errors.Is(&HTTPError{StatusCode: 401}, ErrUnauthorized))
+	assert.True(errors.Is(&HTTPError{StatusCode: 403}, ErrUnauthorized))
+	assert.True(errors.Is(&HTTPError{StatusCode: 404}, ErrNotFound))
+	assert.True(errors.Is(&HTTPError{StatusCode: 429}, ErrRateLimited))
+	assert.True(errors.Is(&HTTPError{StatusCode: 400}, ErrValidation))
+	assert.False(errors.Is(&HTTPError{StatusCode: 500}, ErrNotFound))
+	assert.False(errors.Is(&HTTPError{StatusCode: 429}, ErrUnauthorized))
+}
+
+func TestErrors_Is(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(errors.Is(&AuthenticationError{}, ErrUnauthorized))
+	assert.True(errors.Is(ValidationError{"foo": "is required"}, ErrValidation))
+	assert.True(errors.Is(&UpdateError{Message: "Required Data is Missing."}, ErrValidation))
+	assert.True(errors.Is(&deleteError{Message: "Test Not Found"}, ErrNotFound))
+	assert.False(errors.Is(&deleteError{Message: "this is an error"}, ErrNotFound))
+	assert.False(errors.Is(&deleteError{Message: "Contact Group Not Found"}, ErrNotFound))
+	assert.True(errors.Is(fmt.Errorf("wrapped: %w", &notFoundError{id: "1"}), ErrNotFound))
+}
+
+func TestAuthenticationError_ErrNo(t *testing.T) {
+	tests := []struct {
+		errNo              int
+		invalidCredentials bool
+		accountDisabled    bool
+	}{
+		{ErrNoMissingCredentials, true, false},
+		{ErrNoInvalidKey, true, false},
+		{ErrNoAccountDisabled, false, true},
+		{3, false, false},
+	}
+
+	for _, tt := range tests {
+		err := fmt.Errorf("wrapped: %w", &AuthenticationError{ErrNo: tt.errNo, Message: "foo"})
+
+		assert.Equal(t, tt.invalidCredentials, IsInvalidKey(err), "ErrNo %d", tt.errNo)
+		assert.Equal(t, tt.accountDisabled, IsAccountDisabled(err), "ErrNo %d", tt.errNo)
+		assert.True(t, errors.Is(err, ErrUnauthorized), "ErrNo %d", tt.errNo)
+	}
+
+	assert.False(t, IsInvalidKey(errors.New("foo")))
+	assert.False(t, IsAccountDisabled(nil))
+}
+
+func TestContactGroups_Detail_NotFound(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	c := &fakeAPIClient{
+		fixture: "contactGroupListAllOk.json",
+	}
+	tt := newContactGroups(c)
+
+	_, err := tt.Detail(1)
+	require.NotNil(err)
+	assert.Equal("1 Not found", err.Error())
+	assert.True(errors.Is(err, ErrNotFound))
+}
+
+func TestSsls_Detail_NotFound(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	c := &fakeAPIClient{
+		fixture: "sslListAllOk.json",
+	}
+	tt := newSsls(c)
+
+	_, err := tt.Detail("1")
+	require.NotNil(err)
+	assert.Equal("1 Not found", err.Error())
+	assert.True(errors.Is(err, ErrNotFound))
+}
+
+func TestSsls_UpdateError(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	c := &fakeAPIClient{
+		fixture: "ssls_update_error.json",
+	}
+	tt := newSsls(c)
+
+	_, err := tt.Update(&PartialSsl{ID: 1, Domain: "https://example.com"})
+	require.NotNil(err)
+	assert.Equal(&UpdateError{Message: "Error creating test"}, err)
+	assert.True(errors.Is(err, ErrValidation))
+
+	_, err = tt.CreatePartial(&PartialSsl{Domain: "https://example.com"})
+	require.NotNil(err)
+	assert.Equal(&UpdateError{Message: "Error creating test"}, err)
+	assert.True(errors.Is(err, ErrValidation))
+}
+
+func TestContactGroups_UpdateError(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	c := &fakeAPIClient{
+		fixture: "contactGroupUpdateError.json",
+	}
+	tt := newContactGroups(c)
+
+	_, err := tt.Update(&ContactGroup{ContactID: 1, GroupName: "foo"})
+	require.NotNil(err)
+	assert.Equal("Contact Group Not Found", err.Error())
+	assert.True(errors.Is(err, ErrNotFound))
+	assert.False(errors.Is(err, ErrValidation))
+
+	var uerr *UpdateError
+	assert.True(errors.As(err, &uerr))
+
+	assert.True(errors.Is(newContactGroupUpdateError("Email is invalid"), ErrValidation))
+	assert.False(errors.Is(newContactGroupUpdateError("Email is invalid"), ErrNotFound))
+}
+
+func TestNewUpdateError(t *testing.T) {
+	assert := assert.New(t)
+
+	e := newUpdateError("Required Data is Missing.", map[string]interface{}{
+		"WebsiteURL":  "issue b",
+		"WebsiteName": "issue a",
+	})
+	assert.Equal([]UpdateIssue{
+		{Field: "WebsiteName", Message: "issue a"},
+		{Field: "WebsiteURL", Message: "issue b"},
+	}, e.Issues)
+	assert.Equal(ValidationError{"WebsiteName": "issue a", "WebsiteURL": "issue b"}, e.Fields())
+	assert.Equal("Required Data is Missing., WebsiteName issue a, WebsiteURL issue b", e.Error())
+
+	e = newUpdateError("Required Data is Missing.", []interface{}{"hello", "world"})
+	assert.Equal([]UpdateIssue{{Message: "hello"}, {Message: "world"}}, e.Issues)
+	assert.Empty(e.Fields())
+
+	e = newUpdateError("Invalid", "bad test")
+	assert.Equal([]UpdateIssue{{Message: "bad test"}}, e.Issues)
+	assert.Equal("Invalid, bad test", e.Error())
+
+	e = newUpdateError("Invalid", nil)
+	assert.Nil(e.Issues)
+	assert.Equal("Invalid", e.Error())
+}
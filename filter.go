@@ -0,0 +1,102 @@
+package statuscake
+
+import (
+	"net/url"
+	"strings"
+)
+
+// TestFilter selects the Tests returned by Tests.AllMatching.
+//
+// Tags are sent to the API, every other criterion is applied to the Tests it returns.
+// Zero values match every Test.
+type TestFilter struct {
+	// Tags the Tests must have.
+	Tags []string
+
+	// MatchAnyTag returns the Tests having at least one of Tags instead of all of them.
+	MatchAnyTag bool
+
+	// Status at last test.
+	Status Status
+
+	TestType TestType
+
+	// Paused, when set, selects only paused or only running Tests.
+	Paused *bool
+
+	// Name is a case insensitive substring of the WebsiteName.
+	Name string
+
+	// URL is a case insensitive substring of the WebsiteURL.
+	URL string
+}
+
+// ToURLValues returns the query string understood by the API for the filter. A nil filter returns empty values.
+func (f *TestFilter) ToURLValues() url.Values {
+	v := make(url.Values)
+	if f == nil {
+		return v
+	}
+
+	if len(f.Tags) > 0 {
+		v.Set("tags", strings.Join(f.Tags, ","))
+
+		if f.MatchAnyTag {
+			v.Set("matchany", "1")
+		}
+	}
+
+	return v
+}
+
+// Match reports whether t satisfies every criterion of the filter. A nil filter matches every Test.
+func (f *TestFilter) Match(t *Test) bool {
+	if f == nil {
+		return true
+	}
+
+	if len(f.Tags) > 0 && !matchTags(t.TestTags, f.Tags, f.MatchAnyTag) {
+		return false
+	}
+
+	if f.Status != "" && !strings.EqualFold(string(t.Status), string(f.Status)) {
+		return false
+	}
+
+	if f.TestType != "" && !strings.EqualFold(string(t.TestType), string(f.TestType)) {
+		return false
+	}
+
+	if f.Paused != nil && t.Paused != *f.Paused {
+		return false
+	}
+
+	if f.Name != "" && !containsFold(t.WebsiteName, f.Name) {
+		return false
+	}
+
+	if f.URL != "" && !containsFold(t.WebsiteURL, f.URL) {
+		return false
+	}
+
+	return true
+}
+
+func matchTags(testTags []string, tags []string, any bool) bool {
+	has := make(map[string]bool, len(testTags))
+	for _, tag := range testTags {
+		has[tag] = true
+	}
+
+	for _, tag := range tags {
+		if has[tag] == any {
+			return any
+		}
+	}
+
+	return !any
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
@@ -0,0 +1,68 @@
+package statuscake
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTestFilter_ToURLValues(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(url.Values{}, (&TestFilter{Status: "Up", Name: "www"}).ToURLValues())
+	assert.Equal(url.Values{"tags": {"a,b"}}, (&TestFilter{Tags: []string{"a", "b"}}).ToURLValues())
+	assert.Equal(url.Values{"tags": {"a,b"}, "matchany": {"1"}}, (&TestFilter{Tags: []string{"a", "b"}, MatchAnyTag: true}).ToURLValues())
+	assert.Equal(url.Values{}, (&TestFilter{MatchAnyTag: true}).ToURLValues())
+}
+
+func TestTestFilter_Match(t *testing.T) {
+	paused := true
+	running := false
+
+	test := &Test{
+		Paused:      true,
+		TestType:    "HTTP",
+		WebsiteName: "Example Website",
+		WebsiteURL:  "https://www.example.com",
+		Status:      "Down",
+		TestTags:    []string{"web", "prod"},
+	}
+
+	cases := []struct {
+		name   string
+		filter TestFilter
+		match  bool
+	}{
+		{"empty", TestFilter{}, true},
+		{"all tags", TestFilter{Tags: []string{"web", "prod"}}, true},
+		{"missing tag", TestFilter{Tags: []string{"web", "staging"}}, false},
+		{"any tag", TestFilter{Tags: []string{"web", "staging"}, MatchAnyTag: true}, true},
+		{"no tag", TestFilter{Tags: []string{"staging"}, MatchAnyTag: true}, false},
+		{"status", TestFilter{Status: "down"}, true},
+		{"other status", TestFilter{Status: "Up"}, false},
+		{"test type", TestFilter{TestType: "HTTP"}, true},
+		{"other test type", TestFilter{TestType: "TCP"}, false},
+		{"paused", TestFilter{Paused: &paused}, true},
+		{"running", TestFilter{Paused: &running}, false},
+		{"name", TestFilter{Name: "example"}, true},
+		{"other name", TestFilter{Name: "foo"}, false},
+		{"url", TestFilter{URL: "EXAMPLE.com"}, true},
+		{"other url", TestFilter{URL: "example.org"}, false},
+		{"every criterion", TestFilter{Tags: []string{"web"}, Status: "Down", Paused: &paused, Name: "web"}, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.match, c.filter.Match(test))
+		})
+	}
+}
+
+func TestTestFilter_Nil(t *testing.T) {
+	assert := assert.New(t)
+
+	var f *TestFilter
+	assert.Equal(url.Values{}, f.ToURLValues())
+	assert.True(f.Match(&Test{}))
+}
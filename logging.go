@@ -0,0 +1,132 @@
+package statuscake
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+)
+
+// sensitiveValues lists the query string and form values redacted from logs.
+var sensitiveValues = []string{"BasicPass"}
+
+// WithLogger logs the API traffic to l. Requests are logged at debug level,
+// failed requests and errors returned by the API at warn level.
+// Credentials are never logged.
+func WithLogger(l *slog.Logger) Option {
+	return func(c *Client) {
+		c.logger = l
+	}
+}
+
+// RedactValues returns a copy of v where the sensitive values sent to the API,
+// like the BasicPass of a Test, are redacted so that it can be safely logged or dumped.
+func RedactValues(v url.Values) url.Values {
+	v2 := make(url.Values, len(v))
+	for k, vv := range v {
+		v2[k] = append([]string(nil), vv...)
+	}
+
+	for _, k := range sensitiveValues {
+		if _, ok := v2[k]; ok {
+			v2.Set(k, redacted)
+		}
+	}
+
+	return v2
+}
+
+func valuesKeys(v url.Values) []string {
+	keys := make([]string, 0, len(v))
+	for k := range v {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func (c *Client) logRequest(r *http.Request, form url.Values) {
+	if c.logger == nil || !c.logger.Enabled(r.Context(), slog.LevelDebug) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", r.Method),
+		slog.String("path", r.URL.Path),
+		slog.Any("query", valuesKeys(r.URL.Query())),
+		slog.Any("header", RedactHeader(r.Header)),
+	}
+
+	if form != nil {
+		attrs = append(attrs, slog.String("form", RedactValues(form).Encode()))
+	}
+
+	c.logger.LogAttrs(r.Context(), slog.LevelDebug, "statuscake request", attrs...)
+}
+
+func (c *Client) logAttempt(r *http.Request, attempt int, latency time.Duration, resp *http.Response, err error) {
+	if c.logger == nil {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", r.Method),
+		slog.String("path", r.URL.Path),
+		slog.Any("query", valuesKeys(r.URL.Query())),
+		slog.Int("attempt", attempt),
+		slog.Duration("latency", latency),
+	}
+
+	if err == nil {
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+		c.logger.LogAttrs(r.Context(), slog.LevelDebug, "statuscake response", attrs...)
+		return
+	}
+
+	var herr *HTTPError
+	var aerr *AuthenticationError
+	switch {
+	case errors.As(err, &herr):
+		attrs = append(attrs, slog.Int("status", herr.StatusCode), slog.String("body", herr.Body))
+	case errors.As(err, &aerr):
+		attrs = append(attrs, slog.Int("err_no", aerr.ErrNo), slog.String("api_error", aerr.Message))
+	}
+
+	attrs = append(attrs, slog.String("error", err.Error()))
+	c.logger.LogAttrs(r.Context(), slog.LevelWarn, "statuscake request failed", attrs...)
+}
+
+// logAPIError logs the error message of API responses reporting a failure.
+func (c *Client) logAPIError(r *http.Request, body []byte) {
+	if c.logger == nil {
+		return
+	}
+
+	var ar apiErrorResponse
+	if err := json.Unmarshal(body, &ar); err != nil || ar.Success == nil || *ar.Success {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", r.Method),
+		slog.String("path", r.URL.Path),
+	}
+
+	if ar.Message != nil {
+		attrs = append(attrs, slog.Any("api_message", ar.Message))
+	}
+
+	if ar.Error != "" {
+		attrs = append(attrs, slog.String("api_error", ar.Error))
+	}
+
+	if ar.Issues != nil {
+		attrs = append(attrs, slog.Any("api_issues", ar.Issues))
+	}
+
+	c.logger.LogAttrs(r.Context(), slog.LevelWarn, "statuscake API error", attrs...)
+}
@@ -0,0 +1,90 @@
+package statuscake
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLogger(buf *bytes.Buffer) *slog.Logger {
+	return slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+}
+
+func TestWithLogger_Request(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var buf bytes.Buffer
+	c, err := New(Auth{Username: "random-user", Apikey: "my-pass"}, WithLogger(newTestLogger(&buf)))
+	require.Nil(err)
+
+	c.c = &fakeHTTPClient{StatusCode: 200}
+
+	_, err = c.put(context.Background(), "/Tests/Update", url.Values{"BasicUser": {"myuser"}, "BasicPass": {"mypass"}})
+	require.Nil(err)
+
+	logs := buf.String()
+	assert.Contains(logs, "statuscake request")
+	assert.Contains(logs, "method=PUT")
+	assert.Contains(logs, "path=/API/Tests/Update")
+	assert.Contains(logs, "BasicPass=REDACTED")
+	assert.Contains(logs, "BasicUser=myuser")
+	assert.Contains(logs, "statuscake response")
+	assert.Contains(logs, "status=200")
+	assert.Contains(logs, "attempt=1")
+	assert.NotContains(logs, "mypass")
+	assert.NotContains(logs, "my-pass")
+	assert.NotContains(logs, "random-user")
+}
+
+func TestWithLogger_QueryKeys(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var buf bytes.Buffer
+	c, err := New(Auth{Username: "random-user", Apikey: "my-pass"}, WithLogger(newTestLogger(&buf)))
+	require.Nil(err)
+
+	c.c = &fakeHTTPClient{StatusCode: 200}
+
+	_, err = c.get(context.Background(), "/Tests", url.Values{"tags": {"secret-tag"}})
+	require.Nil(err)
+
+	logs := buf.String()
+	assert.Contains(logs, "query=[tags]")
+	assert.NotContains(logs, "secret-tag")
+}
+
+func TestWithLogger_Errors(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var buf bytes.Buffer
+	c, err := New(Auth{Username: "random-user", Apikey: "my-pass"}, WithLogger(newTestLogger(&buf)))
+	require.Nil(err)
+
+	c.c = &fakeHTTPClient{StatusCode: 200, Fixture: "tests_update_error.json"}
+
+	_, err = c.Tests().Update(&Test{WebsiteName: "foo"})
+	require.NotNil(err)
+
+	logs := buf.String()
+	assert.Contains(logs, "statuscake API error")
+	assert.Contains(logs, `api_message="Required Data is Missing."`)
+	assert.Contains(logs, "issue a")
+
+	buf.Reset()
+	c.c = &fakeHTTPClient{StatusCode: 503}
+
+	_, err = c.Tests().All()
+	require.NotNil(err)
+
+	logs = buf.String()
+	assert.Contains(logs, "statuscake request failed")
+	assert.Contains(logs, "status=503")
+}
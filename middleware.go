@@ -0,0 +1,72 @@
+package statuscake
+
+import (
+	"net/http"
+)
+
+// redacted replaces sensitive values in logs and dumps.
+const redacted = "REDACTED"
+
+// RoundTripFunc sends a request to the API and returns its response.
+type RoundTripFunc func(*http.Request) (*http.Response, error)
+
+// Middleware wraps a RoundTripFunc. It can inspect or modify the request before calling next,
+// and inspect the response or the error returned by next.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// WithMiddleware adds middlewares to the Client. Middlewares are called in the given order,
+// so the first one sees the request first and the response last.
+// Middlewares are called once per attempt when retries are enabled.
+func WithMiddleware(m ...Middleware) Option {
+	return func(c *Client) {
+		c.middlewares = append(c.middlewares, m...)
+	}
+}
+
+// BeforeRequest returns a Middleware calling fn before sending each request.
+// If fn returns an error the request isn't sent and the error is returned.
+func BeforeRequest(fn func(*http.Request) error) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(r *http.Request) (*http.Response, error) {
+			if err := fn(r); err != nil {
+				return nil, err
+			}
+
+			return next(r)
+		}
+	}
+}
+
+// AfterResponse returns a Middleware calling fn with each request and the response or the error it got.
+func AfterResponse(fn func(*http.Request, *http.Response, error)) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(r *http.Request) (*http.Response, error) {
+			resp, err := next(r)
+			fn(r, resp, err)
+
+			return resp, err
+		}
+	}
+}
+
+// RedactHeader returns a copy of h where the credentials sent to the API are redacted,
+// so that it can be safely logged or dumped.
+func RedactHeader(h http.Header) http.Header {
+	h2 := h.Clone()
+	for _, k := range []string{"Username", "API"} {
+		if h2.Get(k) != "" {
+			h2.Set(k, redacted)
+		}
+	}
+
+	return h2
+}
+
+func (c *Client) roundTrip(r *http.Request) (*http.Response, error) {
+	next := RoundTripFunc(c.c.Do)
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		next = c.middlewares[i](next)
+	}
+
+	return next(r)
+}
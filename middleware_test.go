@@ -0,0 +1,108 @@
+package statuscake
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithMiddleware(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var calls []string
+	trace := func(name string) Middleware {
+		return func(next RoundTripFunc) RoundTripFunc {
+			return func(r *http.Request) (*http.Response, error) {
+				calls = append(calls, "before "+name)
+				resp, err := next(r)
+				calls = append(calls, "after "+name)
+				return resp, err
+			}
+		}
+	}
+
+	c, err := New(Auth{Username: "random-user", Apikey: "my-pass"}, WithMiddleware(trace("a"), trace("b")))
+	require.Nil(err)
+
+	hc := &fakeHTTPClient{StatusCode: 200}
+	c.c = hc
+
+	_, err = c.get(context.Background(), "/hello", nil)
+	require.Nil(err)
+
+	assert.Equal([]string{"before a", "before b", "after b", "after a"}, calls)
+}
+
+func TestBeforeRequest(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	c, err := New(Auth{Username: "random-user", Apikey: "my-pass"}, WithMiddleware(BeforeRequest(func(r *http.Request) error {
+		r.Header.Set("Traceparent", "00-foo-bar-01")
+		return nil
+	})))
+	require.Nil(err)
+
+	hc := &fakeHTTPClient{StatusCode: 200}
+	c.c = hc
+
+	_, err = c.get(context.Background(), "/hello", nil)
+	require.Nil(err)
+	require.Len(hc.requests, 1)
+	assert.Equal("00-foo-bar-01", hc.requests[0].Header.Get("Traceparent"))
+}
+
+func TestBeforeRequest_Error(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	expected := errors.New("nope")
+	c, err := New(Auth{Username: "random-user", Apikey: "my-pass"}, WithMiddleware(BeforeRequest(func(r *http.Request) error {
+		return expected
+	})))
+	require.Nil(err)
+
+	hc := &fakeHTTPClient{StatusCode: 200}
+	c.c = hc
+
+	_, err = c.get(context.Background(), "/hello", nil)
+	assert.Equal(expected, err)
+	assert.Empty(hc.requests)
+}
+
+func TestAfterResponse(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var statusCodes []int
+	c, err := New(Auth{Username: "random-user", Apikey: "my-pass"}, WithMiddleware(AfterResponse(func(r *http.Request, resp *http.Response, err error) {
+		statusCodes = append(statusCodes, resp.StatusCode)
+	})))
+	require.Nil(err)
+
+	c.c = &fakeHTTPClient{StatusCode: 500}
+
+	_, err = c.get(context.Background(), "/hello", nil)
+	require.NotNil(err)
+	assert.Equal([]int{500}, statusCodes)
+}
+
+func TestRedactHeader(t *testing.T) {
+	assert := assert.New(t)
+
+	h := http.Header{}
+	h.Set("Username", "random-user")
+	h.Set("API", "my-pass")
+	h.Set("Content-Type", "application/json")
+
+	r := RedactHeader(h)
+	assert.Equal("REDACTED", r.Get("Username"))
+	assert.Equal("REDACTED", r.Get("API"))
+	assert.Equal("application/json", r.Get("Content-Type"))
+	assert.Equal("my-pass", h.Get("API"))
+}
@@ -0,0 +1,42 @@
+package statuscake
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Option configures a Client created with New.
+type Option func(*Client)
+
+// WithHTTPClient sets the http.Client used to send requests. A nil hc is ignored.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		if hc != nil {
+			c.c = hc
+		}
+	}
+}
+
+// WithBaseURL sets the base URL of the API. It defaults to https://app.statuscake.com/API.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithTimeout sets the timeout of the http.Client used to send requests.
+// It's applied after all the other options, so it can be combined with WithHTTPClient;
+// in that case the given http.Client is copied and left untouched.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = d
+	}
+}
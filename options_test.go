@@ -0,0 +1,70 @@
+package statuscake
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithHTTPClient(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	hc := &http.Client{}
+	c, err := New(Auth{Username: "random-user", Apikey: "my-pass"}, WithHTTPClient(hc))
+	require.Nil(err)
+
+	assert.True(hc == c.c)
+}
+
+func TestWithHTTPClient_Nil(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	c, err := New(Auth{Username: "random-user", Apikey: "my-pass"}, WithHTTPClient(nil))
+	require.Nil(err)
+
+	require.IsType(&http.Client{}, c.c)
+	assert.NotNil(c.c.(*http.Client))
+}
+
+func TestWithBaseURL(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	c, err := New(Auth{Username: "random-user", Apikey: "my-pass"}, WithBaseURL("http://localhost:8080/API/"))
+	require.Nil(err)
+
+	r, err := c.newRequest(context.Background(), "GET", "/hello", nil, nil)
+	require.Nil(err)
+	assert.Equal("http://localhost:8080/API/hello", r.URL.String())
+}
+
+func TestWithUserAgent(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	c, err := New(Auth{Username: "random-user", Apikey: "my-pass"}, WithUserAgent("my-tool/1.0"))
+	require.Nil(err)
+
+	r, err := c.newRequest(context.Background(), "GET", "/hello", nil, nil)
+	require.Nil(err)
+	assert.Equal("my-tool/1.0", r.Header.Get("User-Agent"))
+}
+
+func TestWithTimeout(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	hc := &http.Client{}
+	c, err := New(Auth{Username: "random-user", Apikey: "my-pass"}, WithTimeout(5*time.Second), WithHTTPClient(hc))
+	require.Nil(err)
+
+	require.IsType(&http.Client{}, c.c)
+	assert.Equal(5*time.Second, c.c.(*http.Client).Timeout)
+	assert.Equal(time.Duration(0), hc.Timeout)
+}
@@ -0,0 +1,74 @@
+package statuscake
+
+import (
+	"net/url"
+	"reflect"
+)
+
+// TestPatch is a partial update of an existing Test. Only the fields that are not nil are sent,
+// the other settings of the Test are left untouched.
+//
+//	err := c.Tests().Patch(testID, &statuscake.TestPatch{
+//		FindString:     statuscake.Ptr("Welcome"),
+//		FollowRedirect: statuscake.Ptr(false),
+//	})
+//
+// See Test for the meaning of each field.
+type TestPatch struct {
+	Paused         *bool     `querystring:"Paused"`
+	WebsiteName    *string   `querystring:"WebsiteName"`
+	CustomHeader   *string   `querystring:"CustomHeader"`
+	UserAgent      *string   `querystring:"UserAgent"`
+	WebsiteURL     *string   `querystring:"WebsiteURL"`
+	Port           *int      `querystring:"Port"`
+	ContactGroup   *[]string `querystring:"ContactGroup"`
+	NodeLocations  *[]string `querystring:"NodeLocations"`
+	Timeout        *int      `querystring:"Timeout"`
+	PingURL        *string   `querystring:"PingURL"`
+	Confirmation   *int      `querystring:"Confirmation"`
+	CheckRate      *int      `querystring:"CheckRate"`
+	BasicUser      *string   `querystring:"BasicUser"`
+	BasicPass      *string   `querystring:"BasicPass"`
+	Public         *IntBool  `querystring:"Public"`
+	LogoImage      *string   `querystring:"LogoImage"`
+	Branding       *IntBool  `querystring:"Branding"`
+	WebsiteHost    *string   `querystring:"WebsiteHost"`
+	Virus          *IntBool  `querystring:"Virus"`
+	FindString     *string   `querystring:"FindString"`
+	DoNotFind      *bool     `querystring:"DoNotFind"`
+	TestType       *TestType `querystring:"TestType"`
+	RealBrowser    *IntBool  `querystring:"RealBrowser"`
+	TriggerRate    *int      `querystring:"TriggerRate"`
+	TestTags       *[]string `querystring:"TestTags"`
+	StatusCodes    *string   `querystring:"StatusCodes"`
+	UseJar         *IntBool  `querystring:"UseJar"`
+	PostRaw        *string   `querystring:"PostRaw"`
+	PostBody       *string   `querystring:"PostBody"`
+	FinalEndpoint  *string   `querystring:"FinalEndpoint"`
+	EnableSSLAlert *bool     `querystring:"EnableSSLAlert"`
+	FollowRedirect *bool     `querystring:"FollowRedirect"`
+	DNSServer      *string   `querystring:"DNSServer"`
+	DNSIP          *string   `querystring:"DNSIP"`
+}
+
+// Ptr returns a pointer to v, to set the fields of a TestPatch.
+func Ptr[T any](v T) *T {
+	return &v
+}
+
+// ToURLValues returns url.Values of the fields set in the patch.
+func (p TestPatch) ToURLValues() url.Values {
+	values := make(url.Values)
+	st := reflect.TypeOf(p)
+	sv := reflect.ValueOf(p)
+	for i := 0; i < st.NumField(); i++ {
+		v := sv.Field(i)
+		if v.IsNil() {
+			continue
+		}
+
+		values.Set(st.Field(i).Tag.Get(queryStringTag), valueToQueryStringValue(v.Elem()))
+	}
+
+	return values
+}
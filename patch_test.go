@@ -0,0 +1,61 @@
+package statuscake
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTestPatch_ToURLValues(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(url.Values{}, TestPatch{}.ToURLValues())
+
+	p := TestPatch{
+		Paused:         Ptr(true),
+		FindString:     Ptr(""),
+		FollowRedirect: Ptr(false),
+		Port:           Ptr(443),
+		Public:         Ptr[IntBool](true),
+		TestType:       Ptr(TestTypeHEAD),
+		TestTags:       Ptr([]string{"web", "prod"}),
+		ContactGroup:   Ptr([]string{}),
+	}
+
+	assert.Equal(url.Values{
+		"Paused":         {"1"},
+		"FindString":     {""},
+		"FollowRedirect": {"0"},
+		"Port":           {"443"},
+		"Public":         {"1"},
+		"TestType":       {"HEAD"},
+		"TestTags":       {"web,prod"},
+		"ContactGroup":   {""},
+	}, p.ToURLValues())
+}
+
+func TestTestPatch_Fields(t *testing.T) {
+	// every field sent by Test.ToURLValues, except TestID, can be patched
+	patchFields := make(map[string]reflect.Type)
+	pt := reflect.TypeOf(TestPatch{})
+	for i := 0; i < pt.NumField(); i++ {
+		patchFields[pt.Field(i).Tag.Get(queryStringTag)] = pt.Field(i).Type.Elem()
+	}
+
+	testFields := 0
+	tt := reflect.TypeOf(Test{})
+	for i := 0; i < tt.NumField(); i++ {
+		f := tt.Field(i)
+		tag := f.Tag.Get(queryStringTag)
+		if tag == "" || tag == "TestID" {
+			continue
+		}
+		testFields++
+
+		assert.Equal(t, f.Type, patchFields[tag], tag)
+	}
+
+	assert.Len(t, patchFields, testFields)
+}
@@ -0,0 +1,52 @@
+package statuscake
+
+import (
+	"time"
+)
+
+// Period is a span of time during which a Test kept the same Status.
+type Period struct {
+	// Status of the Test during the Period, Up or Down.
+	Status Status
+
+	Start time.Time
+
+	// End is the zero time while the Period is ongoing.
+	End time.Time
+
+	// Duration is the time between Start and End, 0 while the Period is ongoing.
+	Duration time.Duration
+
+	// Additional details given by the API, if any.
+	Additional string
+}
+
+// Ongoing reports whether the Period has not ended yet.
+func (p *Period) Ongoing() bool {
+	return p.End.IsZero()
+}
+
+type periodResponse struct {
+	Status     Status `json:"Status"`
+	Start      string `json:"Start"`
+	End        string `json:"End"`
+	StartUnix  int64  `json:"Start_Unix"`
+	EndUnix    int64  `json:"End_Unix"`
+	Additional string `json:"Additional"`
+	Period     string `json:"Period"`
+}
+
+func (p *periodResponse) period() *Period {
+	period := &Period{
+		Status:     p.Status,
+		Start:      time.Unix(p.StartUnix, 0).UTC(),
+		Additional: p.Additional,
+	}
+
+	if p.EndUnix > 0 {
+		period.End = time.Unix(p.EndUnix, 0).UTC()
+		period.Duration = period.End.Sub(period.Start)
+	}
+
+	return period
+}
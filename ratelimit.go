@@ -0,0 +1,83 @@
+package statuscake
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// WithRateLimit limits the requests sent by the Client to rps requests per second,
+// allowing bursts of up to burst requests. Requests exceeding the limit wait for their turn
+// until their context is done. The limit is shared by Tests, Ssls and ContactGroups.
+func WithRateLimit(rps float64, burst int) Option {
+	return func(c *Client) {
+		c.limiter = newRateLimiter(rps, burst)
+	}
+}
+
+// rateLimiter is a token bucket refilled at rate tokens per second up to burst tokens.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(rps float64, burst int) *rateLimiter {
+	if rps <= 0 {
+		return nil
+	}
+
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &rateLimiter{
+		rate:   rps,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// wait blocks until a token is available or ctx is done.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	for {
+		d := l.reserve(time.Now())
+		if d == 0 {
+			return nil
+		}
+
+		if err := sleep(ctx, d); err != nil {
+			return err
+		}
+	}
+}
+
+// reserve takes a token if available and returns 0, otherwise it returns how long
+// to wait before the next token is available.
+func (l *rateLimiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens += elapsed.Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+		l.last = now
+	}
+
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+
+	d := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+	if d <= 0 {
+		d = time.Nanosecond
+	}
+
+	return d
+}
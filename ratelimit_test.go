@@ -0,0 +1,76 @@
+package statuscake
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter_reserve(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	l := newRateLimiter(2, 2)
+	require.NotNil(l)
+
+	now := l.last
+	assert.Equal(time.Duration(0), l.reserve(now))
+	assert.Equal(time.Duration(0), l.reserve(now))
+	assert.Equal(500*time.Millisecond, l.reserve(now))
+
+	now = now.Add(250 * time.Millisecond)
+	assert.Equal(250*time.Millisecond, l.reserve(now))
+
+	now = now.Add(250 * time.Millisecond)
+	assert.Equal(time.Duration(0), l.reserve(now))
+
+	// tokens never exceed the burst
+	now = now.Add(time.Hour)
+	assert.Equal(time.Duration(0), l.reserve(now))
+	assert.Equal(time.Duration(0), l.reserve(now))
+	assert.Equal(500*time.Millisecond, l.reserve(now))
+}
+
+func TestRateLimiter_Disabled(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Nil(newRateLimiter(0, 10))
+}
+
+func TestRateLimiter_wait_ContextDone(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	l := newRateLimiter(0.001, 1)
+	require.Nil(l.wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.Equal(context.DeadlineExceeded, l.wait(ctx))
+}
+
+func TestClient_doRequest_WithRateLimit(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	c, err := New(Auth{Username: "random-user", Apikey: "my-pass"}, WithRateLimit(0.001, 1))
+	require.Nil(err)
+
+	hc := &fakeHTTPClient{StatusCode: 200}
+	c.c = hc
+
+	_, err = c.Tests().Detail(1)
+	assert.NotEqual(context.DeadlineExceeded, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = c.Ssls().AllContext(ctx)
+	require.NotNil(err)
+	assert.Contains(err.Error(), context.DeadlineExceeded.Error())
+	assert.Len(hc.requests, 1)
+}
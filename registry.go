@@ -0,0 +1,270 @@
+package statuscake
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Registry holds the Clients of several StatusCake accounts, identified by name,
+// and runs the same query across all of them.
+type Registry struct {
+	mu      sync.RWMutex
+	clients map[string]*Client
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		clients: make(map[string]*Client),
+	}
+}
+
+// LoadRegistry returns a Registry with a Client for each profile of the credentials file at path,
+// named after the profile. See FileCredentials for the format of the file and its default path.
+// The options are applied to every Client.
+func LoadRegistry(path string, opts ...Option) (*Registry, error) {
+	if path == "" {
+		path = defaultCredentialsPath()
+	}
+
+	profiles, err := readCredentialsFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	r := NewRegistry()
+	for profile := range profiles {
+		p := FileCredentials{Path: path, Profile: profile}
+		clientOpts := append([]Option{WithCredentials(NewCachedCredentials(p, 0))}, opts...)
+
+		c, err := New(Auth{}, clientOpts...)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := r.Register(profile, c); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+// Register adds the Client of the named account.
+func (r *Registry) Register(account string, c *Client) error {
+	if account == "" {
+		return fmt.Errorf("account name is required")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.clients[account]; ok {
+		return fmt.Errorf("account `%s` is already registered", account)
+	}
+	r.clients[account] = c
+
+	return nil
+}
+
+// Client returns the Client of the named account.
+func (r *Registry) Client(account string) (*Client, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	c, ok := r.clients[account]
+	return c, ok
+}
+
+// Accounts returns the names of the registered accounts, sorted.
+func (r *Registry) Accounts() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	accounts := make([]string, 0, len(r.clients))
+	for account := range r.clients {
+		accounts = append(accounts, account)
+	}
+	sort.Strings(accounts)
+
+	return accounts
+}
+
+// AccountErrors implements the error interface and maps the name of each failed account to its error.
+type AccountErrors map[string]error
+
+func (e AccountErrors) Error() string {
+	accounts := make([]string, 0, len(e))
+	for account := range e {
+		accounts = append(accounts, account)
+	}
+	sort.Strings(accounts)
+
+	var messages []string
+	for _, account := range accounts {
+		messages = append(messages, fmt.Sprintf("%s: %s", account, e[account]))
+	}
+
+	return strings.Join(messages, ", ")
+}
+
+// Unwrap returns the errors of all the accounts, so that they can be inspected with errors.Is and errors.As.
+func (e AccountErrors) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+	for _, err := range e {
+		errs = append(errs, err)
+	}
+
+	return errs
+}
+
+// Each calls fn concurrently for every account. It returns an AccountErrors
+// with the errors returned by fn, or nil if all calls succeeded.
+func (r *Registry) Each(ctx context.Context, fn func(ctx context.Context, account string, c *Client) error) error {
+	r.mu.RLock()
+	clients := make(map[string]*Client, len(r.clients))
+	for account, c := range r.clients {
+		clients[account] = c
+	}
+	r.mu.RUnlock()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	errs := make(AccountErrors)
+
+	for account, c := range clients {
+		wg.Add(1)
+		go func(account string, c *Client) {
+			defer wg.Done()
+
+			if err := fn(ctx, account, c); err != nil {
+				mu.Lock()
+				errs[account] = err
+				mu.Unlock()
+			}
+		}(account, c)
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// AccountTest is a Test tagged with the name of its account.
+type AccountTest struct {
+	Account string
+	*Test
+}
+
+// AccountSsl is an Ssl tagged with the name of its account.
+type AccountSsl struct {
+	Account string
+	*Ssl
+}
+
+// AccountContactGroup is a ContactGroup tagged with the name of its account.
+type AccountContactGroup struct {
+	Account string
+	*ContactGroup
+}
+
+// collect runs fn for every account and returns the results sorted by account name.
+func collect[T any](ctx context.Context, r *Registry, fn func(ctx context.Context, c *Client) ([]T, error)) (map[string][]T, []string, error) {
+	var mu sync.Mutex
+	results := make(map[string][]T)
+
+	err := r.Each(ctx, func(ctx context.Context, account string, c *Client) error {
+		res, err := fn(ctx, c)
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		results[account] = res
+		mu.Unlock()
+
+		return nil
+	})
+
+	accounts := make([]string, 0, len(results))
+	for account := range results {
+		accounts = append(accounts, account)
+	}
+	sort.Strings(accounts)
+
+	return results, accounts, err
+}
+
+// AllTests returns the Tests of every account. When some accounts fail, it returns the Tests
+// of the others along with an AccountErrors.
+func (r *Registry) AllTests(ctx context.Context) ([]AccountTest, error) {
+	return r.AllTestsWithFilter(ctx, nil)
+}
+
+// AllTestsWithFilter is like AllTests but filters the Tests like Tests.AllWithFilter.
+func (r *Registry) AllTestsWithFilter(ctx context.Context, filterOptions url.Values) ([]AccountTest, error) {
+	return r.allTests(ctx, func(ctx context.Context, c *Client) ([]*Test, error) {
+		return c.Tests().AllWithFilterContext(ctx, filterOptions)
+	})
+}
+
+// AllTestsMatching is like AllTests but filters the Tests like Tests.AllMatching.
+func (r *Registry) AllTestsMatching(ctx context.Context, f *TestFilter) ([]AccountTest, error) {
+	return r.allTests(ctx, func(ctx context.Context, c *Client) ([]*Test, error) {
+		return c.Tests().AllMatchingContext(ctx, f)
+	})
+}
+
+func (r *Registry) allTests(ctx context.Context, fn func(context.Context, *Client) ([]*Test, error)) ([]AccountTest, error) {
+	results, accounts, err := collect(ctx, r, fn)
+
+	var tests []AccountTest
+	for _, account := range accounts {
+		for _, t := range results[account] {
+			tests = append(tests, AccountTest{Account: account, Test: t})
+		}
+	}
+
+	return tests, err
+}
+
+// AllSsls returns the Ssls of every account. When some accounts fail, it returns the Ssls
+// of the others along with an AccountErrors.
+func (r *Registry) AllSsls(ctx context.Context) ([]AccountSsl, error) {
+	results, accounts, err := collect(ctx, r, func(ctx context.Context, c *Client) ([]*Ssl, error) {
+		return c.Ssls().AllContext(ctx)
+	})
+
+	var ssls []AccountSsl
+	for _, account := range accounts {
+		for _, s := range results[account] {
+			ssls = append(ssls, AccountSsl{Account: account, Ssl: s})
+		}
+	}
+
+	return ssls, err
+}
+
+// AllContactGroups returns the ContactGroups of every account. When some accounts fail,
+// it returns the ContactGroups of the others along with an AccountErrors.
+func (r *Registry) AllContactGroups(ctx context.Context) ([]AccountContactGroup, error) {
+	results, accounts, err := collect(ctx, r, func(ctx context.Context, c *Client) ([]*ContactGroup, error) {
+		return c.ContactGroups().AllContext(ctx)
+	})
+
+	var groups []AccountContactGroup
+	for _, account := range accounts {
+		for _, g := range results[account] {
+			groups = append(groups, AccountContactGroup{Account: account, ContactGroup: g})
+		}
+	}
+
+	return groups, err
+}
@@ -0,0 +1,115 @@
+package statuscake
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRegistryTestClient(t *testing.T, hc httpClient) *Client {
+	c, err := New(Auth{Username: "random-user", Apikey: "my-pass"})
+	require.Nil(t, err)
+	c.c = hc
+
+	return c
+}
+
+func TestRegistry_Register(t *testing.T) {
+	assert := assert.New(t)
+
+	r := NewRegistry()
+	c := newRegistryTestClient(t, &fakeHTTPClient{})
+
+	assert.Nil(r.Register("prod", c))
+	assert.Nil(r.Register("staging", c))
+	assert.NotNil(r.Register("prod", c))
+	assert.NotNil(r.Register("", c))
+
+	assert.Equal([]string{"prod", "staging"}, r.Accounts())
+
+	c2, ok := r.Client("prod")
+	assert.True(ok)
+	assert.True(c == c2)
+
+	_, ok = r.Client("missing")
+	assert.False(ok)
+}
+
+func TestRegistry_AllTests(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	r := NewRegistry()
+	require.Nil(r.Register("staging", newRegistryTestClient(t, &fakeHTTPClient{StatusCode: 200, Fixture: "tests_all_ok.json"})))
+	require.Nil(r.Register("prod", newRegistryTestClient(t, &fakeHTTPClient{StatusCode: 200, Fixture: "tests_all_ok.json"})))
+	require.Nil(r.Register("broken", newRegistryTestClient(t, &fakeHTTPClient{StatusCode: 401})))
+
+	tests, err := r.AllTests(context.Background())
+	require.NotNil(err)
+
+	require.IsType(AccountErrors{}, err)
+	assert.Len(err.(AccountErrors), 1)
+	assert.Contains(err.Error(), "broken: HTTP error: 401")
+	assert.True(errors.Is(err, ErrUnauthorized))
+
+	require.Len(tests, 4)
+	assert.Equal("prod", tests[0].Account)
+	assert.Equal(100, tests[0].TestID)
+	assert.Equal("prod", tests[1].Account)
+	assert.Equal(101, tests[1].TestID)
+	assert.Equal("staging", tests[2].Account)
+	assert.Equal("staging", tests[3].Account)
+}
+
+func TestRegistry_AllSsls(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	r := NewRegistry()
+	require.Nil(r.Register("prod", newRegistryTestClient(t, &fakeHTTPClient{StatusCode: 200, Fixture: "sslListAllOk.json"})))
+
+	ssls, err := r.AllSsls(context.Background())
+	require.Nil(err)
+	require.Len(ssls, 3)
+	assert.Equal("prod", ssls[0].Account)
+	assert.Equal("143615", ssls[0].ID)
+}
+
+func TestRegistry_AllContactGroups(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	r := NewRegistry()
+	require.Nil(r.Register("prod", newRegistryTestClient(t, &fakeHTTPClient{StatusCode: 200, Fixture: "contactGroupListAllOk.json"})))
+
+	groups, err := r.AllContactGroups(context.Background())
+	require.Nil(err)
+	require.Len(groups, 3)
+	assert.Equal("prod", groups[0].Account)
+	assert.Equal(12345, groups[0].ContactID)
+}
+
+func TestLoadRegistry(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	p := filepath.Join(t.TempDir(), "credentials")
+	err := ioutil.WriteFile(p, []byte("[prod]\nusername = a\napikey = 1\n\n[staging]\nusername = b\napikey = 2\n"), 0600)
+	require.Nil(err)
+
+	r, err := LoadRegistry(p, WithUserAgent("audit/1.0"))
+	require.Nil(err)
+	assert.Equal([]string{"prod", "staging"}, r.Accounts())
+
+	c, _ := r.Client("staging")
+	req, err := c.newRequest(context.Background(), "GET", "/Tests", nil, nil)
+	require.Nil(err)
+	assert.Equal("b", req.Header.Get("Username"))
+	assert.Equal("2", req.Header.Get("API"))
+	assert.Equal("audit/1.0", req.Header.Get("User-Agent"))
+}
@@ -6,10 +6,18 @@ import (
 )
 
 type autheticationErrorResponse struct {
-	ErrNo int
+	ErrNo *jsonNumberString
 	Error string
 }
 
+// apiErrorResponse matches the fields used by the API to report failures.
+type apiErrorResponse struct {
+	Success *bool       `json:"Success"`
+	Message interface{} `json:"Message"`
+	Error   string      `json:"Error"`
+	Issues  interface{} `json:"Issues"`
+}
+
 type updateResponse struct {
 	Issues   interface{} `json:"Issues"`
 	Success  bool        `json:"Success"`
@@ -31,16 +39,22 @@ type contactGroupDetailResponse struct {
 type detailResponse struct {
 	Method           string                       `json:"Method"`
 	TestID           int                          `json:"TestID"`
-	TestType         string                       `json:"TestType"`
+	TestType         TestType                     `json:"TestType"`
 	Paused           bool                         `json:"Paused"`
 	WebsiteName      string                       `json:"WebsiteName"`
 	URI              string                       `json:"URI"`
 	ContactID        int                          `json:"ContactID"`
 	ContactGroups    []contactGroupDetailResponse `json:"ContactGroups"`
-	Status           string                       `json:"Status"`
+	Status           Status                       `json:"Status"`
 	Uptime           float64                      `json:"Uptime"`
 	CustomHeader     string                       `json:"CustomHeader"`
 	UserAgent        string                       `json:"UserAgent"`
+	PingURL          string                       `json:"PingURL"`
+	BasicUser        string                       `json:"BasicUser"`
+	Public           IntBool                      `json:"Public"`
+	Branding         IntBool                      `json:"Branding"`
+	Virus            IntBool                      `json:"Virus"`
+	RealBrowser      IntBool                      `json:"RealBrowser"`
 	CheckRate        int                          `json:"CheckRate"`
 	Timeout          int                          `json:"Timeout"`
 	LogoImage        string                       `json:"LogoImage"`
@@ -49,7 +63,7 @@ type detailResponse struct {
 	NodeLocations    []string                     `json:"NodeLocations"`
 	FindString       string                       `json:"FindString"`
 	DoNotFind        bool                         `json:"DoNotFind"`
-	LastTested       string                       `json:"LastTested"`
+	LastTested       apiDateTime                  `json:"LastTested"`
 	NextLocation     string                       `json:"NextLocation"`
 	Port             int                          `json:"Port"`
 	Processing       bool                         `json:"Processing"`
@@ -58,7 +72,7 @@ type detailResponse struct {
 	DownTimes        int                          `json:"DownTimes,string"`
 	Sensitive        bool                         `json:"Sensitive"`
 	TriggerRate      int                          `json:"TriggerRate,string"`
-	UseJar           int                          `json:"UseJar"`
+	UseJar           IntBool                      `json:"UseJar"`
 	PostRaw          string                       `json:"PostRaw"`
 	PostBody         string                       `json:"PostBody"`
 	FinalEndpoint    string                       `json:"FinalEndpoint"`
@@ -84,6 +98,12 @@ func (d *detailResponse) test() *Test {
 		WebsiteURL:     d.URI,
 		CustomHeader:   d.CustomHeader,
 		UserAgent:      d.UserAgent,
+		PingURL:        d.PingURL,
+		BasicUser:      d.BasicUser,
+		Public:         d.Public,
+		Branding:       d.Branding,
+		Virus:          d.Virus,
+		RealBrowser:    d.RealBrowser,
 		ContactID:      d.ContactID,
 		ContactGroup:   contactGroupIds,
 		Status:         d.Status,
@@ -108,5 +128,13 @@ func (d *detailResponse) test() *Test {
 		FollowRedirect: d.FollowRedirect,
 		StatusCodes:    strings.Join(d.StatusCodes[:], ","),
 		TestTags:       d.Tags,
+
+		LastTested:      d.LastTested.Time,
+		NextLocation:    d.NextLocation,
+		Processing:      d.Processing,
+		ProcessingState: d.ProcessingState,
+		ProcessingOn:    d.ProcessingOn,
+		DownTimes:       d.DownTimes,
+		Sensitive:       d.Sensitive,
 	}
 }
@@ -0,0 +1,174 @@
+package statuscake
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures how the Client retries requests that failed with a
+// transport error or with one of the retryable status codes.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one. Values lower than 2 disable retries.
+	MaxAttempts int
+
+	// MinBackoff is the base delay before the first retry, it doubles at every following attempt.
+	MinBackoff time.Duration
+
+	// MaxBackoff caps the delay between two attempts, including the one requested by a Retry-After header.
+	MaxBackoff time.Duration
+
+	// StatusCodes is the list of HTTP status codes that are retried.
+	StatusCodes []int
+
+	// Methods is the list of HTTP methods that are retried.
+	Methods []string
+}
+
+// DefaultRetryPolicy returns a RetryPolicy suitable for most usages.
+// Requests creating new resources are never retried, to avoid creating duplicates.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  500 * time.Millisecond,
+		MaxBackoff:  30 * time.Second,
+		StatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		Methods: []string{"GET", "PUT", "DELETE"},
+	}
+}
+
+// WithRetryPolicy enables retries of failed requests following p.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = p
+	}
+}
+
+type noRetryKey struct{}
+
+// withoutRetry returns a context that disables retries for the requests using it.
+// It's used by requests that aren't safe to send twice, like the ones creating new resources.
+func withoutRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noRetryKey{}, true)
+}
+
+func retryDisabled(ctx context.Context) bool {
+	v, _ := ctx.Value(noRetryKey{}).(bool)
+	return v
+}
+
+func (p *RetryPolicy) attempts(r *http.Request) int {
+	if p.MaxAttempts < 2 || retryDisabled(r.Context()) {
+		return 1
+	}
+
+	for _, m := range p.Methods {
+		if m == r.Method {
+			return p.MaxAttempts
+		}
+	}
+
+	return 1
+}
+
+// retryable reports whether err is a transport failure or an HTTPError with one of the retryable status codes.
+// Other errors, like the ones returned by middlewares or by the read-only and dry-run checks, are never retried.
+func (p *RetryPolicy) retryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		for _, code := range p.StatusCodes {
+			if code == httpErr.StatusCode {
+				return true
+			}
+		}
+		return false
+	}
+
+	var netErr net.Error
+	var urlErr *url.Error
+
+	return errors.As(err, &netErr) || errors.As(err, &urlErr)
+}
+
+// backoff returns how long to wait before the given retry.
+// It returns the delay requested by the API with Retry-After if any,
+// and an exponential backoff with jitter otherwise.
+func (p *RetryPolicy) backoff(retry int, err error) time.Duration {
+	if e, ok := err.(*HTTPError); ok {
+		if d, ok := parseRetryAfter(e.Header.Get("Retry-After")); ok {
+			if p.MaxBackoff > 0 && d > p.MaxBackoff {
+				return p.MaxBackoff
+			}
+			return d
+		}
+	}
+
+	d := p.MinBackoff
+	for i := 1; i < retry && (p.MaxBackoff <= 0 || d < p.MaxBackoff); i++ {
+		d *= 2
+	}
+
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+
+	if d <= 0 {
+		return 0
+	}
+
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(d-half)+1))
+}
+
+// parseRetryAfter parses the value of a Retry-After header,
+// which is either a number of seconds or an HTTP date.
+func parseRetryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+
+	if s, err := strconv.Atoi(v); err == nil {
+		if s < 0 {
+			return 0, false
+		}
+		return time.Duration(s) * time.Second, true
+	}
+
+	t, err := http.ParseTime(v)
+	if err != nil {
+		return 0, false
+	}
+
+	d := time.Until(t)
+	if d < 0 {
+		d = 0
+	}
+
+	return d, true
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
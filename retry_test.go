@@ -0,0 +1,274 @@
+package statuscake
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       string
+	Err        error
+}
+
+// sequenceHTTPClient returns the given responses in order, repeating the last one.
+type sequenceHTTPClient struct {
+	responses []fakeResponse
+	requests  []*http.Request
+	bodies    []string
+}
+
+func (c *sequenceHTTPClient) Do(r *http.Request) (*http.Response, error) {
+	c.requests = append(c.requests, r)
+
+	var body string
+	if r.Body != nil {
+		b, _ := ioutil.ReadAll(r.Body)
+		body = string(b)
+	}
+	c.bodies = append(c.bodies, body)
+
+	i := len(c.requests) - 1
+	if i >= len(c.responses) {
+		i = len(c.responses) - 1
+	}
+	fr := c.responses[i]
+	if fr.Err != nil {
+		return nil, fr.Err
+	}
+
+	return &http.Response{
+		StatusCode: fr.StatusCode,
+		Header:     fr.Header,
+		Body:       &fakeBody{Reader: bytes.NewReader([]byte(fr.Body))},
+	}, nil
+}
+
+func testRetryPolicy() RetryPolicy {
+	p := DefaultRetryPolicy()
+	p.MinBackoff = time.Millisecond
+	p.MaxBackoff = 5 * time.Millisecond
+	return p
+}
+
+func TestClient_doRequest_RetriesRetryableStatusCodes(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	c, err := New(Auth{Username: "random-user", Apikey: "my-pass"}, WithRetryPolicy(testRetryPolicy()))
+	require.Nil(err)
+
+	hc := &sequenceHTTPClient{
+		responses: []fakeResponse{
+			{StatusCode: 503},
+			{Err: &url.Error{Op: "Put", URL: "/hello", Err: errors.New("connection reset by peer")}},
+			{StatusCode: 200, Body: `{"Success":true}`},
+		},
+	}
+	c.c = hc
+
+	resp, err := c.put(context.Background(), "/hello", url.Values{"foo": {"bar"}})
+	require.Nil(err)
+	require.NotNil(resp)
+
+	assert.Len(hc.requests, 3)
+	assert.Equal([]string{"foo=bar", "foo=bar", "foo=bar"}, hc.bodies)
+}
+
+func TestRetryPolicy_retryable(t *testing.T) {
+	p := DefaultRetryPolicy()
+
+	cases := []struct {
+		name      string
+		err       error
+		retryable bool
+	}{
+		{"nil", nil, false},
+		{"transport", &url.Error{Op: "Get", URL: "/hello", Err: errors.New("connection reset by peer")}, true},
+		{"net", &net.OpError{Op: "dial", Err: errors.New("connection refused")}, true},
+		{"canceled", &url.Error{Op: "Get", URL: "/hello", Err: context.Canceled}, false},
+		{"deadline", context.DeadlineExceeded, false},
+		{"retryable status code", &HTTPError{StatusCode: 503}, true},
+		{"other status code", &HTTPError{StatusCode: 500}, false},
+		{"authentication", &AuthenticationError{Message: "nope"}, false},
+		{"read only", &ReadOnlyError{Method: "PUT", Path: "/hello"}, false},
+		{"other", errors.New("nope"), false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.retryable, p.retryable(c.err))
+		})
+	}
+}
+
+func TestClient_doRequest_DoesNotRetryMiddlewareErrors(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	calls := 0
+	expected := errors.New("nope")
+	c, err := New(Auth{Username: "random-user", Apikey: "my-pass"}, WithRetryPolicy(testRetryPolicy()), WithMiddleware(BeforeRequest(func(r *http.Request) error {
+		calls++
+		return expected
+	})))
+	require.Nil(err)
+
+	hc := &sequenceHTTPClient{
+		responses: []fakeResponse{{StatusCode: 200}},
+	}
+	c.c = hc
+
+	_, err = c.get(context.Background(), "/hello", nil)
+	assert.Equal(expected, err)
+	assert.Equal(1, calls)
+	assert.Empty(hc.requests)
+}
+
+func TestClient_doRequest_StopsAfterMaxAttempts(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	c, err := New(Auth{Username: "random-user", Apikey: "my-pass"}, WithRetryPolicy(testRetryPolicy()))
+	require.Nil(err)
+
+	hc := &sequenceHTTPClient{
+		responses: []fakeResponse{{StatusCode: 502}},
+	}
+	c.c = hc
+
+	_, err = c.get(context.Background(), "/hello", nil)
+	require.NotNil(err)
+	assert.IsType(&HTTPError{}, err)
+	assert.Len(hc.requests, 3)
+}
+
+func TestClient_doRequest_DoesNotRetryOtherStatusCodes(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	c, err := New(Auth{Username: "random-user", Apikey: "my-pass"}, WithRetryPolicy(testRetryPolicy()))
+	require.Nil(err)
+
+	hc := &sequenceHTTPClient{
+		responses: []fakeResponse{{StatusCode: 400}},
+	}
+	c.c = hc
+
+	_, err = c.get(context.Background(), "/hello", nil)
+	require.NotNil(err)
+	assert.Len(hc.requests, 1)
+}
+
+func TestClient_doRequest_DoesNotRetryByDefault(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	c, err := New(Auth{Username: "random-user", Apikey: "my-pass"})
+	require.Nil(err)
+
+	hc := &sequenceHTTPClient{
+		responses: []fakeResponse{{StatusCode: 503}},
+	}
+	c.c = hc
+
+	_, err = c.get(context.Background(), "/hello", nil)
+	require.NotNil(err)
+	assert.Len(hc.requests, 1)
+}
+
+func TestClient_doRequest_DoesNotRetryCreations(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	c, err := New(Auth{Username: "random-user", Apikey: "my-pass"}, WithRetryPolicy(testRetryPolicy()))
+	require.Nil(err)
+
+	hc := &sequenceHTTPClient{
+		responses: []fakeResponse{{StatusCode: 503}},
+	}
+	c.c = hc
+
+	_, err = c.Tests().Update(&Test{WebsiteName: "foo"})
+	require.NotNil(err)
+	assert.Len(hc.requests, 1)
+
+	_, err = c.Tests().Update(&Test{TestID: 1234, WebsiteName: "foo"})
+	require.NotNil(err)
+	assert.Len(hc.requests, 4)
+}
+
+func TestClient_doRequest_StopsWhenContextIsDone(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	p := testRetryPolicy()
+	p.MinBackoff = time.Hour
+	p.MaxBackoff = time.Hour
+	c, err := New(Auth{Username: "random-user", Apikey: "my-pass"}, WithRetryPolicy(p))
+	require.Nil(err)
+
+	hc := &sequenceHTTPClient{
+		responses: []fakeResponse{{StatusCode: 503}},
+	}
+	c.c = hc
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = c.get(ctx, "/hello", nil)
+	assert.Equal(context.DeadlineExceeded, err)
+	assert.Len(hc.requests, 1)
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	assert := assert.New(t)
+
+	p := RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	for i := 0; i < 20; i++ {
+		d := p.backoff(1, nil)
+		assert.True(d >= 50*time.Millisecond && d <= 100*time.Millisecond, d)
+
+		d = p.backoff(3, nil)
+		assert.True(d >= 200*time.Millisecond && d <= 400*time.Millisecond, d)
+
+		d = p.backoff(10, nil)
+		assert.True(d >= 500*time.Millisecond && d <= time.Second, d)
+	}
+
+	err := &HTTPError{StatusCode: 429, Header: http.Header{"Retry-After": {"2"}}}
+	assert.Equal(time.Second, p.backoff(1, err))
+
+	p.MaxBackoff = time.Minute
+	assert.Equal(2*time.Second, p.backoff(1, err))
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	assert := assert.New(t)
+
+	d, ok := parseRetryAfter("120")
+	assert.True(ok)
+	assert.Equal(2*time.Minute, d)
+
+	d, ok = parseRetryAfter(time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	assert.True(ok)
+	assert.True(d > 59*time.Minute && d <= time.Hour, d)
+
+	_, ok = parseRetryAfter("")
+	assert.False(ok)
+
+	_, ok = parseRetryAfter("soon")
+	assert.False(ok)
+}
@@ -1,6 +1,7 @@
 package statuscake
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
@@ -135,13 +136,20 @@ type sslCreateResponse struct {
 //Ssls represent the actions done with the API
 type Ssls interface {
 	All() ([]*Ssl, error)
-	completeSsl(*PartialSsl) (*Ssl, error)
+	AllContext(context.Context) ([]*Ssl, error)
+	completeSsl(context.Context, *PartialSsl) (*Ssl, error)
 	Detail(string) (*Ssl, error)
+	DetailContext(context.Context, string) (*Ssl, error)
 	Update(*PartialSsl) (*Ssl, error)
+	UpdateContext(context.Context, *PartialSsl) (*Ssl, error)
 	UpdatePartial(*PartialSsl) (*PartialSsl, error)
+	UpdatePartialContext(context.Context, *PartialSsl) (*PartialSsl, error)
 	Delete(ID string) error
+	DeleteContext(ctx context.Context, ID string) error
 	CreatePartial(*PartialSsl) (*PartialSsl, error)
+	CreatePartialContext(context.Context, *PartialSsl) (*PartialSsl, error)
 	Create(*PartialSsl) (*Ssl, error)
+	CreateContext(context.Context, *PartialSsl) (*Ssl, error)
 }
 
 func consolidateSsl(s *Ssl) {
@@ -155,11 +163,20 @@ func findSsl(responses []*Ssl, id string) (*Ssl, error) {
 			return elem, nil
 		}
 	}
-	return response, fmt.Errorf("%s Not found", id)
+	return response, &notFoundError{id: id}
 }
 
-func (tt *ssls) completeSsl(s *PartialSsl) (*Ssl, error) {
-	full, err := tt.Detail(strconv.Itoa((*s).ID))
+func (tt *ssls) completeSsl(ctx context.Context, s *PartialSsl) (*Ssl, error) {
+	if tt.dryRun {
+		// nothing was sent to the API, return the state the request would have led to
+		return fromPartial(s), nil
+	}
+
+	if (*s).ID == 0 {
+		return nil, fmt.Errorf("Error creating StatusCake Ssl: the API returned no ID")
+	}
+
+	full, err := tt.DetailContext(ctx, strconv.Itoa((*s).ID))
 	if err != nil {
 		return nil, err
 	}
@@ -167,6 +184,27 @@ func (tt *ssls) completeSsl(s *PartialSsl) (*Ssl, error) {
 	return full, nil
 }
 
+func fromPartial(s *PartialSsl) *Ssl {
+	checkrate, _ := strconv.Atoi(s.Checkrate)
+	ssl := &Ssl{
+		ID:             strconv.Itoa(s.ID),
+		Domain:         s.Domain,
+		Checkrate:      checkrate,
+		ContactGroupsC: s.ContactGroupsC,
+		ContactGroups:  []string{},
+		AlertAt:        s.AlertAt,
+		AlertReminder:  s.AlertReminder,
+		AlertExpiry:    s.AlertExpiry,
+		AlertBroken:    s.AlertBroken,
+		AlertMixed:     s.AlertMixed,
+	}
+	if s.ContactGroupsC != "" {
+		ssl.ContactGroups = strings.Split(s.ContactGroupsC, ",")
+	}
+
+	return ssl
+}
+
 //Partial return a PartialSsl corresponding to the Ssl
 func Partial(s *Ssl) (*PartialSsl, error) {
 	if s == nil {
@@ -192,20 +230,35 @@ func Partial(s *Ssl) (*PartialSsl, error) {
 
 type ssls struct {
 	client apiClient
+	dryRun bool
+}
+
+//NewSsls return a new ssls using c to talk to the API
+func NewSsls(c *Client) Ssls {
+	return newSsls(c)
 }
 
-//NewSsls return a new ssls
-func NewSsls(c apiClient) Ssls {
-	return &ssls{
+func newSsls(c apiClient) Ssls {
+	ss := &ssls{
 		client: c,
 	}
+	if cl, ok := c.(*Client); ok {
+		ss.dryRun = cl.dryRun
+	}
+
+	return ss
 }
 
 //All return a list of all the ssl from the API
 func (tt *ssls) All() ([]*Ssl, error) {
-	rawResponse, err := tt.client.get("/SSL", nil)
+	return tt.AllContext(context.Background())
+}
+
+//AllContext is like All but uses ctx for the API request
+func (tt *ssls) AllContext(ctx context.Context) ([]*Ssl, error) {
+	rawResponse, err := tt.client.get(ctx, "/SSL", nil)
 	if err != nil {
-		return nil, fmt.Errorf("Error getting StatusCake Ssl: %s", err.Error())
+		return nil, fmt.Errorf("Error getting StatusCake Ssl: %w", err)
 	}
 	var getResponse []*Ssl
 	err = json.NewDecoder(rawResponse.Body).Decode(&getResponse)
@@ -222,7 +275,12 @@ func (tt *ssls) All() ([]*Ssl, error) {
 
 //Detail return the ssl corresponding to the id
 func (tt *ssls) Detail(id string) (*Ssl, error) {
-	responses, err := tt.All()
+	return tt.DetailContext(context.Background(), id)
+}
+
+//DetailContext is like Detail but uses ctx for the API request
+func (tt *ssls) DetailContext(ctx context.Context, id string) (*Ssl, error) {
+	responses, err := tt.AllContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -235,18 +293,28 @@ func (tt *ssls) Detail(id string) (*Ssl, error) {
 
 //Update update the API with s and create one if s.ID=0 then return the corresponding Ssl
 func (tt *ssls) Update(s *PartialSsl) (*Ssl, error) {
+	return tt.UpdateContext(context.Background(), s)
+}
+
+//UpdateContext is like Update but uses ctx for the API requests
+func (tt *ssls) UpdateContext(ctx context.Context, s *PartialSsl) (*Ssl, error) {
 	var err error
-	s, err = tt.UpdatePartial(s)
+	s, err = tt.UpdatePartialContext(ctx, s)
 	if err != nil {
 		return nil, err
 	}
-	return tt.completeSsl(s)
+	return tt.completeSsl(ctx, s)
 }
 
 //UpdatePartial update the API with s and create one if s.ID=0 then return the corresponding PartialSsl
 func (tt *ssls) UpdatePartial(s *PartialSsl) (*PartialSsl, error) {
+	return tt.UpdatePartialContext(context.Background(), s)
+}
+
+//UpdatePartialContext is like UpdatePartial but uses ctx for the API request
+func (tt *ssls) UpdatePartialContext(ctx context.Context, s *PartialSsl) (*PartialSsl, error) {
 	if (*s).ID == 0 {
-		return tt.CreatePartial(s)
+		return tt.CreatePartialContext(ctx, s)
 	}
 
 	var v url.Values
@@ -256,9 +324,9 @@ func (tt *ssls) UpdatePartial(s *PartialSsl) (*PartialSsl, error) {
 		v, _ = query.Values(us)
 	}
 
-	rawResponse, err := tt.client.put("/SSL/Update", v)
+	rawResponse, err := tt.client.put(ctx, "/SSL/Update", v)
 	if err != nil {
-		return nil, fmt.Errorf("Error creating StatusCake Ssl: %s", err.Error())
+		return nil, fmt.Errorf("Error creating StatusCake Ssl: %w", err)
 	}
 
 	var updateResponse sslUpdateResponse
@@ -268,7 +336,7 @@ func (tt *ssls) UpdatePartial(s *PartialSsl) (*PartialSsl, error) {
 	}
 
 	if !updateResponse.Success {
-		return nil, fmt.Errorf("%s", updateResponse.Message.(string))
+		return nil, newUpdateError(fmt.Sprint(updateResponse.Message), nil)
 	}
 
 	return s, nil
@@ -276,7 +344,12 @@ func (tt *ssls) UpdatePartial(s *PartialSsl) (*PartialSsl, error) {
 
 //Delete delete the ssl which ID is id
 func (tt *ssls) Delete(id string) error {
-	_, err := tt.client.delete("/SSL/Update", url.Values{"id": {fmt.Sprint(id)}})
+	return tt.DeleteContext(context.Background(), id)
+}
+
+//DeleteContext is like Delete but uses ctx for the API request
+func (tt *ssls) DeleteContext(ctx context.Context, id string) error {
+	_, err := tt.client.delete(ctx, "/SSL/Update", url.Values{"id": {fmt.Sprint(id)}})
 	if err != nil {
 		return err
 	}
@@ -286,16 +359,26 @@ func (tt *ssls) Delete(id string) error {
 
 //Create create the ssl with the data in s and return the Ssl created
 func (tt *ssls) Create(s *PartialSsl) (*Ssl, error) {
+	return tt.CreateContext(context.Background(), s)
+}
+
+//CreateContext is like Create but uses ctx for the API requests
+func (tt *ssls) CreateContext(ctx context.Context, s *PartialSsl) (*Ssl, error) {
 	var err error
-	s, err = tt.CreatePartial(s)
+	s, err = tt.CreatePartialContext(ctx, s)
 	if err != nil {
 		return nil, err
 	}
-	return tt.completeSsl(s)
+	return tt.completeSsl(ctx, s)
 }
 
 //CreatePartial create the ssl with the data in s and return the PartialSsl created
 func (tt *ssls) CreatePartial(s *PartialSsl) (*PartialSsl, error) {
+	return tt.CreatePartialContext(context.Background(), s)
+}
+
+//CreatePartialContext is like CreatePartial but uses ctx for the API request
+func (tt *ssls) CreatePartialContext(ctx context.Context, s *PartialSsl) (*PartialSsl, error) {
 	(*s).ID = 0
 	var v url.Values
 	{
@@ -304,9 +387,9 @@ func (tt *ssls) CreatePartial(s *PartialSsl) (*PartialSsl, error) {
 		v, _ = query.Values(cs)
 	}
 
-	rawResponse, err := tt.client.put("/SSL/Update", v)
+	rawResponse, err := tt.client.put(withoutRetry(ctx), "/SSL/Update", v)
 	if err != nil {
-		return nil, fmt.Errorf("Error creating StatusCake Ssl: %s", err.Error())
+		return nil, fmt.Errorf("Error creating StatusCake Ssl: %w", err)
 	}
 
 	var createResponse sslCreateResponse
@@ -316,10 +399,14 @@ func (tt *ssls) CreatePartial(s *PartialSsl) (*PartialSsl, error) {
 	}
 
 	if !createResponse.Success {
-		return nil, fmt.Errorf("%s", createResponse.Message.(string))
+		return nil, newUpdateError(fmt.Sprint(createResponse.Message), nil)
+	}
+	id, ok := createResponse.Message.(float64)
+	if !ok {
+		return nil, fmt.Errorf("Error creating StatusCake Ssl: unexpected ID %v", createResponse.Message)
 	}
 	createResponse.Input.toPartial(s)
-	(*s).ID = int(createResponse.Message.(float64))
+	(*s).ID = int(id)
 
 	return s, nil
 }
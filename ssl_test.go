@@ -1,6 +1,7 @@
 package statuscake
 
 import (
+	"context"
 	"testing"
 	//"fmt"
 	"github.com/stretchr/testify/assert"
@@ -15,7 +16,7 @@ func TestSsl_All(t *testing.T) {
 	c := &fakeAPIClient{
 		fixture: "sslListAllOk.json",
 	}
-	tt := NewSsls(c)
+	tt := newSsls(c)
 	ssls, err := tt.All()
 	require.Nil(err)
 
@@ -79,7 +80,7 @@ func TestSsls_Detail_OK(t *testing.T) {
 	c := &fakeAPIClient{
 		fixture: "sslListAllOk.json",
 	}
-	tt := NewSsls(c)
+	tt := newSsls(c)
 
 	ssl, err := tt.Detail("143616")
 	require.Nil(err)
@@ -135,7 +136,7 @@ func TestSsls_CreatePartial_OK(t *testing.T) {
 	c := &fakeAPIClient{
 		fixture: "sslCreateOk.json",
 	}
-	tt := NewSsls(c)
+	tt := newSsls(c)
 	partial := &PartialSsl{
 		Domain: "https://www.exemple.com",
 		Checkrate: "2073600",
@@ -173,7 +174,7 @@ func TestSsls_UpdatePartial_OK(t *testing.T) {
 	c := &fakeAPIClient{
 		fixture: "sslUpdateOk.json",
 	}
-	tt := NewSsls(c)
+	tt := newSsls(c)
 	partial := &PartialSsl{
 		ID: 143616,
 		Domain: "https://www.exemple.com",
@@ -211,7 +212,7 @@ func TestSsl_complete_OK(t *testing.T) {
 	c := &fakeAPIClient{
 		fixture: "sslListAllOk.json",
 	}
-	tt := NewSsls(c)
+	tt := newSsls(c)
 
 	partial := &PartialSsl {
 		ID: 143616,
@@ -224,7 +225,7 @@ func TestSsl_complete_OK(t *testing.T) {
 		AlertMixed: true,
 		AlertAt: "7,18,2019",
 	}
-	full, err := tt.completeSsl(partial)
+	full, err := tt.completeSsl(context.Background(), partial)
 	require.Nil(err)
 	mixed := make(map[string]string)
 	flags := make(map[string]bool)
@@ -333,7 +334,7 @@ func TestSsls_Delete_OK(t *testing.T) {
 	c := &fakeAPIClient{
 		fixture: "sslDeleteOk.json",
 	}
-	tt := NewSsls(c)
+	tt := newSsls(c)
 
 	err := tt.Delete("143616")
 	require.Nil(err)
@@ -341,3 +342,21 @@ func TestSsls_Delete_OK(t *testing.T) {
 	assert.Equal("DELETE", c.sentRequestMethod)
 	assert.Equal(c.sentRequestValues,url.Values(url.Values{"id":[]string{"143616"},},))
 }
+
+func TestSsl_CreateWithoutID(t *testing.T) {
+	assert := assert.New(t)
+
+	c := &fakeAPIClient{
+		fixture: "sslCreateNoID.json",
+	}
+	tt := newSsls(c)
+
+	ssl, err := tt.Create(&PartialSsl{Domain: "https://www.exemple.com", Checkrate: "2073600"})
+	assert.Error(err)
+	assert.Nil(ssl)
+
+	// outside of dry-run an Ssl without ID isn't made up
+	ssl, err = tt.completeSsl(context.Background(), &PartialSsl{Domain: "https://www.exemple.com"})
+	assert.Error(err)
+	assert.Nil(ssl)
+}
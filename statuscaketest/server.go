@@ -0,0 +1,768 @@
+// Package statuscaketest provides an in-memory fake of the statuscake.com API,
+// to test code using the statuscake package end to end without network access.
+//
+//	s := statuscaketest.NewServer(statuscake.Auth{Username: "user", Apikey: "key"})
+//	defer s.Close()
+//
+//	c, err := s.Client()
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//
+//	t2, err := c.Tests().Update(&statuscake.Test{WebsiteName: "Foo", WebsiteURL: "https://example.com", TestType: "HTTP"})
+package statuscaketest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/DreamItGetIT/statuscake"
+)
+
+// dateTimeLayout is the layout of the dates returned by the API.
+const dateTimeLayout = "2006-01-02 15:04:05"
+
+// firstID is the ID given to the first resource created on a Server.
+const firstID = 1000
+
+var testTypes = []string{"HTTP", "HEAD", "TCP", "PING", "DNS", "SMTP", "SSH", "PUSH"}
+
+// Server is an in-memory fake of the statuscake.com API serving
+// /Tests, /Tests/Details, /Tests/Update, /Tests/Periods, /Tests/Checks, /Alerts, /SSL, /SSL/Update, /ContactGroups and /ContactGroups/Update.
+type Server struct {
+	*httptest.Server
+
+	// Auth holds the credentials accepted by the Server.
+	Auth statuscake.Auth
+
+	mu            sync.Mutex
+	nextID        int
+	tests         map[int]url.Values
+	periods       map[int][]*statuscake.Period
+	alerts        map[int][]*statuscake.Alert
+	checks        map[int][]*statuscake.Check
+	ssls          map[int]url.Values
+	contactGroups map[int]url.Values
+}
+
+// NewServer starts and returns a Server accepting the given credentials. Callers should call Close when finished.
+func NewServer(auth statuscake.Auth) *Server {
+	s := &Server{
+		Auth:          auth,
+		nextID:        firstID,
+		tests:         make(map[int]url.Values),
+		periods:       make(map[int][]*statuscake.Period),
+		alerts:        make(map[int][]*statuscake.Alert),
+		checks:        make(map[int][]*statuscake.Check),
+		ssls:          make(map[int]url.Values),
+		contactGroups: make(map[int]url.Values),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/Tests", s.authenticated(s.handleTests))
+	mux.HandleFunc("/Tests/Details", s.authenticated(s.handleTestDetails))
+	mux.HandleFunc("/Tests/Update", s.authenticated(s.handleTestUpdate))
+	mux.HandleFunc("/Tests/Periods", s.authenticated(s.handleTestPeriods))
+	mux.HandleFunc("/Tests/Checks", s.authenticated(s.handleTestChecks))
+	mux.HandleFunc("/Alerts", s.authenticated(s.handleAlerts))
+	mux.HandleFunc("/SSL", s.authenticated(s.handleSsls))
+	mux.HandleFunc("/SSL/Update", s.authenticated(s.handleSslUpdate))
+	mux.HandleFunc("/ContactGroups", s.authenticated(s.handleContactGroups))
+	mux.HandleFunc("/ContactGroups/Update", s.authenticated(s.handleContactGroupUpdate))
+
+	s.Server = httptest.NewServer(mux)
+
+	return s
+}
+
+// Client returns a statuscake.Client using the credentials and the URL of the Server.
+func (s *Server) Client(opts ...statuscake.Option) (*statuscake.Client, error) {
+	return statuscake.New(s.Auth, append([]statuscake.Option{statuscake.WithBaseURL(s.URL)}, opts...)...)
+}
+
+// AddPeriods records periods of the Test with the given ID, most recent first, to be returned by /Tests/Periods.
+func (s *Server) AddPeriods(testID int, periods ...*statuscake.Period) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.periods[testID] = append(s.periods[testID], periods...)
+}
+
+// AddAlerts records Alerts sent for the Test with the given ID, most recent first, to be returned by /Alerts.
+func (s *Server) AddAlerts(testID int, alerts ...*statuscake.Alert) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.alerts[testID] = append(s.alerts[testID], alerts...)
+}
+
+// AddChecks records Checks of the Test with the given ID, oldest first, to be returned by /Tests/Checks.
+func (s *Server) AddChecks(testID int, checks ...*statuscake.Check) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.checks[testID] = append(s.checks[testID], checks...)
+}
+
+func (s *Server) authenticated(h func(http.ResponseWriter, *http.Request)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Username") != s.Auth.Username || r.Header.Get("API") != s.Auth.Apikey {
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"ErrNo": 0,
+				"Error": "Can not access account. Was both Username and API Key provided?",
+			})
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		h(w, r)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func methodNotAllowed(w http.ResponseWriter) {
+	writeJSON(w, http.StatusMethodNotAllowed, map[string]interface{}{
+		"Success": false,
+		"Message": "Method not allowed",
+	})
+}
+
+func (s *Server) newID() int {
+	id := s.nextID
+	s.nextID++
+
+	return id
+}
+
+// form returns the values sent in the body of r.
+func form(r *http.Request) (url.Values, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+
+	return r.PostForm, nil
+}
+
+// merge replaces the values of dst with the ones in src.
+func merge(dst url.Values, src url.Values) {
+	for k, v := range src {
+		dst[k] = v
+	}
+}
+
+func sortedIDs(m map[int]url.Values) []int {
+	ids := make([]int, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	return ids
+}
+
+func atoi(s string) int {
+	i, _ := strconv.Atoi(s)
+	return i
+}
+
+func list(s string) []string {
+	if s == "" {
+		return []string{}
+	}
+
+	return strings.Split(s, ",")
+}
+
+func boolValue(s string) bool {
+	b, _ := strconv.ParseBool(s)
+	return b
+}
+
+func (s *Server) handleTests(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		methodNotAllowed(w)
+		return
+	}
+
+	var tags []string
+	if v := r.URL.Query().Get("tags"); v != "" {
+		tags = strings.Split(v, ",")
+	}
+	matchAny := boolValue(r.URL.Query().Get("matchany"))
+
+	tests := []map[string]interface{}{}
+	for _, id := range sortedIDs(s.tests) {
+		t := s.tests[id]
+		if !hasTags(list(t.Get("TestTags")), tags, matchAny) {
+			continue
+		}
+
+		tests = append(tests, map[string]interface{}{
+			"TestID":        id,
+			"Paused":        boolValue(t.Get("Paused")),
+			"TestType":      t.Get("TestType"),
+			"WebsiteName":   t.Get("WebsiteName"),
+			"WebsiteURL":    t.Get("WebsiteURL"),
+			"ContactGroup":  list(t.Get("ContactGroup")),
+			"Status":        "Up",
+			"Uptime":        100,
+			"NodeLocations": list(t.Get("NodeLocations")),
+			"TestTags":      list(t.Get("TestTags")),
+		})
+	}
+
+	writeJSON(w, http.StatusOK, tests)
+}
+
+func hasTags(testTags []string, tags []string, matchAny bool) bool {
+	if len(tags) == 0 {
+		return true
+	}
+
+	for _, tag := range tags {
+		found := false
+		for _, t := range testTags {
+			if t == tag {
+				found = true
+				break
+			}
+		}
+
+		if found && matchAny {
+			return true
+		}
+
+		if !found && !matchAny {
+			return false
+		}
+	}
+
+	return !matchAny
+}
+
+func (s *Server) handleTestDetails(w http.ResponseWriter, r *http.Request) {
+	id := atoi(r.URL.Query().Get("TestID"))
+	t, ok := s.tests[id]
+
+	switch r.Method {
+	case "GET":
+		if !ok {
+			writeJSON(w, http.StatusNotFound, map[string]interface{}{
+				"Success": false,
+				"Error":   "Test Not Found",
+			})
+			return
+		}
+
+		writeJSON(w, http.StatusOK, s.testDetail(id, t))
+	case "DELETE":
+		if !ok {
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"Success": false,
+				"Error":   "Test Not Found",
+			})
+			return
+		}
+
+		delete(s.tests, id)
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"TestID":   id,
+			"Affected": 1,
+			"Success":  true,
+			"Message":  "This Check Has Been Deleted. It can not be recovered.",
+		})
+	default:
+		methodNotAllowed(w)
+	}
+}
+
+func (s *Server) testDetail(id int, t url.Values) map[string]interface{} {
+	contactGroups := []map[string]interface{}{}
+	for _, cgID := range list(t.Get("ContactGroup")) {
+		cg := s.contactGroups[atoi(cgID)]
+		if cg == nil {
+			continue
+		}
+
+		contactGroups = append(contactGroups, map[string]interface{}{
+			"ID":    atoi(cgID),
+			"Name":  cg.Get("GroupName"),
+			"Email": cg.Get("Email"),
+		})
+	}
+
+	return map[string]interface{}{
+		"Method":           "Website",
+		"TestID":           id,
+		"TestType":         t.Get("TestType"),
+		"Paused":           boolValue(t.Get("Paused")),
+		"WebsiteName":      t.Get("WebsiteName"),
+		"URI":              t.Get("WebsiteURL"),
+		"ContactGroups":    contactGroups,
+		"Status":           "Up",
+		"Uptime":           100,
+		"CustomHeader":     t.Get("CustomHeader"),
+		"UserAgent":        t.Get("UserAgent"),
+		"PingURL":          t.Get("PingURL"),
+		"BasicUser":        t.Get("BasicUser"),
+		"Public":           atoi(t.Get("Public")),
+		"Branding":         atoi(t.Get("Branding")),
+		"Virus":            atoi(t.Get("Virus")),
+		"RealBrowser":      atoi(t.Get("RealBrowser")),
+		"CheckRate":        atoi(t.Get("CheckRate")),
+		"Timeout":          atoi(t.Get("Timeout")),
+		"LogoImage":        t.Get("LogoImage"),
+		"Confirmation":     strconv.Itoa(atoi(t.Get("Confirmation"))),
+		"WebsiteHost":      t.Get("WebsiteHost"),
+		"NodeLocations":    list(t.Get("NodeLocations")),
+		"FindString":       t.Get("FindString"),
+		"DoNotFind":        boolValue(t.Get("DoNotFind")),
+		"LastTested":       "",
+		"NextLocation":     "",
+		"Port":             atoi(t.Get("Port")),
+		"Processing":       false,
+		"ProcessingState":  "Complete",
+		"ProcessingOn":     "",
+		"DownTimes":        "0",
+		"Sensitive":        false,
+		"TriggerRate":      strconv.Itoa(atoi(t.Get("TriggerRate"))),
+		"UseJar":           atoi(t.Get("UseJar")),
+		"PostRaw":          t.Get("PostRaw"),
+		"PostBody":         t.Get("PostBody"),
+		"FinalEndpoint":    t.Get("FinalEndpoint"),
+		"EnableSSLWarning": boolValue(t.Get("EnableSSLAlert")),
+		"FollowRedirect":   boolValue(t.Get("FollowRedirect")),
+		"DNSServer":        t.Get("DNSServer"),
+		"DNSIP":            t.Get("DNSIP"),
+		"StatusCodes":      list(t.Get("StatusCodes")),
+		"Tags":             list(t.Get("TestTags")),
+	}
+}
+
+func (s *Server) handleTestUpdate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "PUT" {
+		methodNotAllowed(w)
+		return
+	}
+
+	v, err := form(r)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]interface{}{"Success": false, "Message": err.Error()})
+		return
+	}
+
+	if v.Get("TestID") != "" {
+		id := atoi(v.Get("TestID"))
+		t, ok := s.tests[id]
+		if !ok {
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"Success": false,
+				"Message": "Test Not Found",
+				"Issues":  map[string]string{"TestID": "does not exist"},
+			})
+			return
+		}
+
+		updated := url.Values{}
+		merge(updated, t)
+		merge(updated, v)
+		if issues := validateTest(updated); len(issues) > 0 {
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"Success": false,
+				"Message": "Required Data is Missing.",
+				"Issues":  issues,
+			})
+			return
+		}
+
+		s.tests[id] = updated
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"Issues":   map[string]string{},
+			"Success":  true,
+			"Message":  "Test Updated",
+			"InsertID": id,
+		})
+		return
+	}
+
+	if issues := validateTest(v); len(issues) > 0 {
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"Success": false,
+			"Message": "Required Data is Missing.",
+			"Issues":  issues,
+		})
+		return
+	}
+
+	id := s.newID()
+	s.tests[id] = v
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"Issues":   map[string]string{},
+		"Success":  true,
+		"Message":  "Test Inserted",
+		"InsertID": id,
+	})
+}
+
+func (s *Server) handleTestPeriods(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		methodNotAllowed(w)
+		return
+	}
+
+	id := atoi(r.URL.Query().Get("TestID"))
+	if _, ok := s.tests[id]; !ok {
+		writeJSON(w, http.StatusNotFound, map[string]interface{}{
+			"Success": false,
+			"Error":   "Test Not Found",
+		})
+		return
+	}
+
+	periods := []map[string]interface{}{}
+	for _, p := range s.periods[id] {
+		end, endUnix, period := "Unknown", int64(0), "Ongoing"
+		if !p.Ongoing() {
+			end, endUnix, period = p.End.Format(dateTimeLayout), p.End.Unix(), p.Duration.String()
+		}
+
+		periods = append(periods, map[string]interface{}{
+			"Status":     p.Status,
+			"Start":      p.Start.Format(dateTimeLayout),
+			"End":        end,
+			"Start_Unix": p.Start.Unix(),
+			"End_Unix":   endUnix,
+			"Additional": p.Additional,
+			"Period":     period,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, periods)
+}
+
+func (s *Server) handleTestChecks(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		methodNotAllowed(w)
+		return
+	}
+
+	q := r.URL.Query()
+	id := atoi(q.Get("TestID"))
+	if _, ok := s.tests[id]; !ok {
+		writeJSON(w, http.StatusNotFound, map[string]interface{}{
+			"Success": false,
+			"Error":   "Test Not Found",
+		})
+		return
+	}
+
+	start, _ := strconv.ParseInt(q.Get("Start"), 10, 64)
+	end, err := strconv.ParseInt(q.Get("End"), 10, 64)
+	if err != nil {
+		end = math.MaxInt64
+	}
+	limit := atoi(q.Get("Limit"))
+
+	checks := map[string]interface{}{}
+	for i, c := range s.checks[id] {
+		if limit > 0 && len(checks) == limit {
+			break
+		}
+
+		if c.Time.Unix() < start || c.Time.Unix() > end {
+			continue
+		}
+
+		checks[strconv.Itoa(i)] = map[string]interface{}{
+			"Location":    c.Location,
+			"Time":        c.Time.Unix(),
+			"Status":      c.StatusCode,
+			"Performance": c.Performance.Milliseconds(),
+		}
+	}
+
+	writeJSON(w, http.StatusOK, checks)
+}
+
+func (s *Server) handleAlerts(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		methodNotAllowed(w)
+		return
+	}
+
+	id := atoi(r.URL.Query().Get("TestID"))
+	if _, ok := s.tests[id]; !ok {
+		writeJSON(w, http.StatusNotFound, map[string]interface{}{
+			"Success": false,
+			"Error":   "Test Not Found",
+		})
+		return
+	}
+
+	since, _ := strconv.ParseInt(r.URL.Query().Get("Since"), 10, 64)
+
+	alerts := []map[string]interface{}{}
+	for _, a := range s.alerts[id] {
+		if a.Triggered.Unix() < since {
+			continue
+		}
+
+		alerts = append(alerts, map[string]interface{}{
+			"Triggered":      a.Triggered.Format(dateTimeLayout),
+			"StatusCode":     a.StatusCode,
+			"Unix":           a.Triggered.Unix(),
+			"Status":         a.Status,
+			"TestID":         id,
+			"Triggered_Unix": a.Triggered.Unix(),
+			"ContactGroups":  a.ContactGroups,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, alerts)
+}
+
+func validateTest(v url.Values) map[string]string {
+	issues := make(map[string]string)
+
+	if v.Get("WebsiteName") == "" {
+		issues["WebsiteName"] = "is required"
+	}
+
+	if v.Get("TestType") == "PUSH" {
+		if v.Get("WebsiteURL") != "" {
+			issues["WebsiteURL"] = "must not be set for PUSH type tests"
+		}
+	} else if v.Get("WebsiteURL") == "" {
+		issues["WebsiteURL"] = "is required"
+	}
+
+	validType := false
+	for _, tt := range testTypes {
+		if v.Get("TestType") == tt {
+			validType = true
+		}
+	}
+	if !validType {
+		issues["TestType"] = fmt.Sprintf("must be one of %s", strings.Join(testTypes, ", "))
+	}
+
+	switch v.Get("TestType") {
+	case "TCP", "SMTP", "SSH":
+		if atoi(v.Get("Port")) == 0 {
+			issues["Port"] = "is required"
+		}
+	}
+
+	if cr := v.Get("CheckRate"); cr != "" && (atoi(cr) < 0 || atoi(cr) > 23999) {
+		issues["CheckRate"] = "must be between 0 and 23999"
+	}
+
+	return issues
+}
+
+func (s *Server) handleSsls(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		methodNotAllowed(w)
+		return
+	}
+
+	ssls := []map[string]interface{}{}
+	for _, id := range sortedIDs(s.ssls) {
+		ssl := s.ssls[id]
+		ssls = append(ssls, map[string]interface{}{
+			"id":               strconv.Itoa(id),
+			"checkrate":        atoi(ssl.Get("checkrate")),
+			"paused":           false,
+			"domain":           ssl.Get("domain"),
+			"issuer_cn":        "",
+			"cert_score":       "",
+			"cipher_score":     "",
+			"cert_status":      "",
+			"cipher":           "",
+			"valid_from_utc":   "",
+			"valid_until_utc":  "",
+			"mixed_content":    []map[string]string{},
+			"flags":            map[string]bool{},
+			"contact_groups":   list(ssl.Get("contact_groups")),
+			"alert_at":         ssl.Get("alert_at"),
+			"last_reminder":    0,
+			"alert_reminder":   boolValue(ssl.Get("alert_reminder")),
+			"alert_expiry":     boolValue(ssl.Get("alert_expiry")),
+			"alert_broken":     boolValue(ssl.Get("alert_broken")),
+			"alert_mixed":      boolValue(ssl.Get("alert_mixed")),
+			"last_updated_utc": "",
+		})
+	}
+
+	writeJSON(w, http.StatusOK, ssls)
+}
+
+func (s *Server) handleSslUpdate(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "PUT":
+		v, err := form(r)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]interface{}{"Success": false, "Message": err.Error()})
+			return
+		}
+
+		if v.Get("domain") == "" {
+			writeJSON(w, http.StatusOK, map[string]interface{}{"Success": false, "Message": "domain is required"})
+			return
+		}
+
+		if v.Get("checkrate") == "" {
+			writeJSON(w, http.StatusOK, map[string]interface{}{"Success": false, "Message": "checkrate is required"})
+			return
+		}
+
+		if v.Get("id") != "" {
+			id := atoi(v.Get("id"))
+			if _, ok := s.ssls[id]; !ok {
+				writeJSON(w, http.StatusOK, map[string]interface{}{"Success": false, "Message": "SSL test not found"})
+				return
+			}
+
+			s.ssls[id] = v
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"Success": true,
+				"Message": "SSL test has been updated successfully",
+			})
+			return
+		}
+
+		id := s.newID()
+		s.ssls[id] = v
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"Success": true,
+			"Message": id,
+			"Input": map[string]interface{}{
+				"domain":         v.Get("domain"),
+				"checkrate":      v.Get("checkrate"),
+				"contact_groups": v.Get("contact_groups"),
+				"alert_reminder": boolValue(v.Get("alert_reminder")),
+				"alert_expiry":   boolValue(v.Get("alert_expiry")),
+				"alert_broken":   boolValue(v.Get("alert_broken")),
+				"alert_mixed":    boolValue(v.Get("alert_mixed")),
+				"alert_at":       v.Get("alert_at"),
+			},
+		})
+	case "DELETE":
+		id := atoi(r.URL.Query().Get("id"))
+		if _, ok := s.ssls[id]; !ok {
+			writeJSON(w, http.StatusOK, map[string]interface{}{"Success": false, "Message": "SSL test not found"})
+			return
+		}
+
+		delete(s.ssls, id)
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"Success": true,
+			"Message": "Deletion successful",
+		})
+	default:
+		methodNotAllowed(w)
+	}
+}
+
+func (s *Server) handleContactGroups(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		methodNotAllowed(w)
+		return
+	}
+
+	groups := []map[string]interface{}{}
+	for _, id := range sortedIDs(s.contactGroups) {
+		cg := s.contactGroups[id]
+		groups = append(groups, map[string]interface{}{
+			"GroupName":    cg.Get("GroupName"),
+			"Emails":       list(cg.Get("Email")),
+			"Mobiles":      cg.Get("Mobile"),
+			"Boxcar":       cg.Get("Boxcar"),
+			"Pushover":     cg.Get("Pushover"),
+			"ContactID":    id,
+			"DesktopAlert": cg.Get("DesktopAlert"),
+			"PingURL":      cg.Get("PingURL"),
+		})
+	}
+
+	writeJSON(w, http.StatusOK, groups)
+}
+
+func (s *Server) handleContactGroupUpdate(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "PUT":
+		v, err := form(r)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]interface{}{"Success": false, "Message": err.Error()})
+			return
+		}
+
+		if v.Get("ContactID") != "" {
+			id := atoi(v.Get("ContactID"))
+			cg, ok := s.contactGroups[id]
+			if !ok {
+				writeJSON(w, http.StatusOK, map[string]interface{}{"Success": false, "Message": "Contact Group Not Found"})
+				return
+			}
+
+			merge(cg, v)
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"Success":    true,
+				"Message":    "Contact Group has been updated!",
+				"error_code": 0,
+				"Issues":     []string{},
+			})
+			return
+		}
+
+		if v.Get("GroupName") == "" {
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"Success":    false,
+				"Message":    "GroupName is required",
+				"error_code": 1,
+				"Issues":     []string{"GroupName is required"},
+			})
+			return
+		}
+
+		id := s.newID()
+		s.contactGroups[id] = v
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"Success":    true,
+			"Message":    "Inserted",
+			"error_code": 0,
+			"Issues":     []string{},
+			"InsertID":   id,
+		})
+	case "DELETE":
+		id := atoi(r.URL.Query().Get("ContactID"))
+		if _, ok := s.contactGroups[id]; !ok {
+			writeJSON(w, http.StatusOK, map[string]interface{}{"Success": false, "Message": "Contact Group Not Found", "error_code": 1})
+			return
+		}
+
+		delete(s.contactGroups, id)
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"Success":    true,
+			"Message":    "Contact Group Deleted",
+			"error_code": 0,
+		})
+	default:
+		methodNotAllowed(w)
+	}
+}
@@ -0,0 +1,475 @@
+package statuscaketest
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DreamItGetIT/statuscake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testAuth = statuscake.Auth{Username: "user", Apikey: "key"}
+
+func newClient(t *testing.T) *statuscake.Client {
+	s := NewServer(testAuth)
+	t.Cleanup(s.Close)
+
+	c, err := s.Client()
+	require.NoError(t, err)
+
+	return c
+}
+
+func TestServer_Tests(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	c := newClient(t)
+
+	cg, err := c.ContactGroups().Create(&statuscake.ContactGroup{GroupName: "ops", Emails: []string{"ops@example.com"}})
+	require.NoError(err)
+
+	created, err := c.Tests().Update(&statuscake.Test{
+		WebsiteName:   "Example",
+		WebsiteURL:    "https://example.com",
+		TestType:      "HTTP",
+		CheckRate:     300,
+		ContactGroup:  []string{"1000"},
+		TestTags:      []string{"web", "prod"},
+		StatusCodes:   "500,502",
+		NodeLocations: []string{"EU1"},
+	})
+	require.NoError(err)
+	assert.Equal(1001, created.TestID)
+	assert.Equal(1000, cg.ContactID)
+
+	all, err := c.Tests().AllWithFilter(map[string][]string{"tags": {"web"}})
+	require.NoError(err)
+	require.Len(all, 1)
+	assert.Equal("Example", all[0].WebsiteName)
+	assert.Equal([]string{"web", "prod"}, all[0].TestTags)
+
+	none, err := c.Tests().AllWithFilter(map[string][]string{"tags": {"staging"}})
+	require.NoError(err)
+	assert.Empty(none)
+
+	matching, err := c.Tests().AllMatching(&statuscake.TestFilter{Tags: []string{"staging", "prod"}, MatchAnyTag: true, Name: "exa"})
+	require.NoError(err)
+	assert.Len(matching, 1)
+
+	detail, err := c.Tests().Detail(created.TestID)
+	require.NoError(err)
+	assert.Equal("https://example.com", detail.WebsiteURL)
+	assert.Equal(300, detail.CheckRate)
+	assert.Equal([]string{"1000"}, detail.ContactGroup)
+	assert.Equal("500,502", detail.StatusCodes)
+
+	detail.WebsiteName = "Renamed"
+	_, err = c.Tests().Update(detail)
+	require.NoError(err)
+
+	detail, err = c.Tests().Detail(created.TestID)
+	require.NoError(err)
+	assert.Equal("Renamed", detail.WebsiteName)
+
+	require.NoError(c.Tests().Delete(created.TestID))
+
+	_, err = c.Tests().Detail(created.TestID)
+	assert.ErrorIs(err, statuscake.ErrNotFound)
+
+	err = c.Tests().Delete(created.TestID)
+	assert.ErrorIs(err, statuscake.ErrNotFound)
+}
+
+func TestServer_DetailUpdateRoundTrip(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	s := NewServer(testAuth)
+	defer s.Close()
+
+	c, err := s.Client()
+	require.NoError(err)
+
+	cg, err := c.ContactGroups().Create(&statuscake.ContactGroup{GroupName: "ops"})
+	require.NoError(err)
+
+	created, err := c.Tests().Update(&statuscake.Test{
+		Paused:         true,
+		WebsiteName:    "Example",
+		CustomHeader:   `{"X-Foo":"bar"}`,
+		UserAgent:      "agent/1.0",
+		WebsiteURL:     "https://example.com",
+		ContactGroup:   []string{strconv.Itoa(cg.ContactID)},
+		NodeLocations:  []string{"EU1", "US1"},
+		Timeout:        30,
+		PingURL:        "https://example.com/ping",
+		Confirmation:   2,
+		CheckRate:      300,
+		BasicUser:      "user",
+		BasicPass:      "secret",
+		Public:         true,
+		LogoImage:      "https://example.com/logo.png",
+		Branding:       true,
+		WebsiteHost:    "host",
+		Virus:          true,
+		FindString:     "Welcome",
+		DoNotFind:      true,
+		TestType:       statuscake.TestTypeHTTP,
+		RealBrowser:    true,
+		TriggerRate:    5,
+		TestTags:       []string{"web", "prod"},
+		StatusCodes:    "500,502",
+		UseJar:         true,
+		PostRaw:        "a=b",
+		FinalEndpoint:  "https://example.com/home",
+		EnableSSLAlert: true,
+		FollowRedirect: true,
+	})
+	require.NoError(err)
+
+	before := url.Values{}
+	merge(before, s.tests[created.TestID])
+
+	detail, err := c.Tests().Detail(created.TestID)
+	require.NoError(err)
+
+	_, err = c.Tests().Update(detail)
+	require.NoError(err)
+
+	// the update only sent the TestID in addition to the stored values
+	after := s.tests[created.TestID]
+	assert.Equal([]string{strconv.Itoa(created.TestID)}, after["TestID"])
+	delete(after, "TestID")
+	assert.Equal(before, after)
+
+	again, err := c.Tests().Detail(created.TestID)
+	require.NoError(err)
+	assert.Equal(detail, again)
+}
+
+func TestServer_Patch(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	c := newClient(t)
+
+	created, err := c.Tests().Update(&statuscake.Test{
+		WebsiteName:    "Example",
+		WebsiteURL:     "https://example.com",
+		TestType:       statuscake.TestTypeHTTP,
+		CheckRate:      300,
+		FindString:     "Welcome",
+		FollowRedirect: true,
+		TestTags:       []string{"web"},
+	})
+	require.NoError(err)
+
+	err = c.Tests().Patch(created.TestID, &statuscake.TestPatch{
+		CheckRate: statuscake.Ptr(60),
+		TestTags:  statuscake.Ptr([]string{"web", "prod"}),
+	})
+	require.NoError(err)
+
+	detail, err := c.Tests().Detail(created.TestID)
+	require.NoError(err)
+	assert.Equal(60, detail.CheckRate)
+	assert.Equal([]string{"web", "prod"}, detail.TestTags)
+	assert.Equal("Welcome", detail.FindString)
+	assert.True(detail.FollowRedirect)
+	assert.Equal("Example", detail.WebsiteName)
+
+	err = c.Tests().Patch(created.TestID, &statuscake.TestPatch{WebsiteName: statuscake.Ptr("")})
+	assert.ErrorIs(err, statuscake.ErrValidation)
+}
+
+func TestServer_PauseWithTags(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	c := newClient(t)
+
+	for _, tags := range [][]string{{"web", "prod"}, {"web"}, {"prod"}} {
+		_, err := c.Tests().Update(&statuscake.Test{
+			WebsiteName:  strings.Join(tags, "-"),
+			WebsiteURL:   "https://example.com",
+			TestType:     "HTTP",
+			TestTags:     tags,
+			CustomHeader: `{"X-Foo":"bar"}`,
+		})
+		require.NoError(err)
+	}
+
+	results, err := c.Tests().PauseWithTags([]string{"web"})
+	require.NoError(err)
+	assert.Equal([]statuscake.PauseResult{
+		{TestID: 1000, WebsiteName: "web-prod"},
+		{TestID: 1001, WebsiteName: "web"},
+	}, results)
+
+	paused := true
+	tests, err := c.Tests().AllMatching(&statuscake.TestFilter{Paused: &paused})
+	require.NoError(err)
+	assert.Len(tests, 2)
+
+	// Pausing only sends the Paused field, the other fields are kept.
+	detail, err := c.Tests().Detail(1000)
+	require.NoError(err)
+	assert.True(detail.Paused)
+	assert.Equal(`{"X-Foo":"bar"}`, detail.CustomHeader)
+
+	results, err = c.Tests().ResumeWithTags([]string{"web", "prod"})
+	require.NoError(err)
+	assert.Equal([]statuscake.PauseResult{{TestID: 1000, WebsiteName: "web-prod"}}, results)
+
+	tests, err = c.Tests().AllMatching(&statuscake.TestFilter{Paused: &paused})
+	require.NoError(err)
+	require.Len(tests, 1)
+	assert.Equal(1001, tests[0].TestID)
+
+	require.NoError(c.Tests().Resume(1001))
+	err = c.Tests().Pause(2000)
+	assert.ErrorIs(err, statuscake.ErrValidation)
+}
+
+func TestServer_Periods(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	s := NewServer(testAuth)
+	defer s.Close()
+
+	c, err := s.Client()
+	require.NoError(err)
+
+	test, err := c.Tests().Update(&statuscake.Test{WebsiteName: "Example", WebsiteURL: "https://example.com", TestType: "HTTP"})
+	require.NoError(err)
+
+	start := time.Date(2019, 3, 1, 10, 0, 0, 0, time.UTC)
+	down := &statuscake.Period{Status: "Down", Start: start, End: start.Add(time.Hour), Duration: time.Hour}
+	up := &statuscake.Period{Status: "Up", Start: start.Add(time.Hour)}
+	s.AddPeriods(test.TestID, up, down)
+
+	periods, err := c.Tests().Periods(test.TestID)
+	require.NoError(err)
+	assert.Equal([]*statuscake.Period{up, down}, periods)
+
+	_, err = c.Tests().Periods(test.TestID + 1)
+	assert.ErrorIs(err, statuscake.ErrNotFound)
+}
+
+func TestServer_Alerts(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	s := NewServer(testAuth)
+	defer s.Close()
+
+	c, err := s.Client()
+	require.NoError(err)
+
+	test, err := c.Tests().Update(&statuscake.Test{WebsiteName: "Example", WebsiteURL: "https://example.com", TestType: "HTTP"})
+	require.NoError(err)
+
+	triggered := time.Date(2019, 3, 1, 10, 0, 0, 0, time.UTC)
+	up := &statuscake.Alert{TestID: test.TestID, Triggered: triggered.Add(time.Hour), Status: "Up", StatusCode: 200, ContactGroups: []string{"5"}}
+	down := &statuscake.Alert{TestID: test.TestID, Triggered: triggered, Status: "Down", StatusCode: 503}
+	s.AddAlerts(test.TestID, up, down)
+
+	alerts, err := c.Tests().Alerts(test.TestID, time.Time{})
+	require.NoError(err)
+	assert.Equal([]*statuscake.Alert{up, down}, alerts)
+
+	alerts, err = c.Tests().Alerts(test.TestID, triggered.Add(time.Minute))
+	require.NoError(err)
+	assert.Equal([]*statuscake.Alert{up}, alerts)
+}
+
+func TestServer_Checks(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	s := NewServer(testAuth)
+	defer s.Close()
+
+	c, err := s.Client()
+	require.NoError(err)
+
+	test, err := c.Tests().Update(&statuscake.Test{WebsiteName: "Example", WebsiteURL: "https://example.com", TestType: "HTTP"})
+	require.NoError(err)
+
+	start := time.Date(2019, 3, 1, 10, 0, 0, 0, time.UTC)
+	var checks []*statuscake.Check
+	for i := 0; i < 5; i++ {
+		checks = append(checks, &statuscake.Check{
+			Time:        start.Add(time.Duration(i) * time.Minute),
+			Location:    "UK1",
+			StatusCode:  200,
+			Performance: time.Duration(100+i) * time.Millisecond,
+		})
+	}
+	// a Check run at the same second from another location, at the end of the first page
+	checks = append(checks[:2], append([]*statuscake.Check{{Time: checks[1].Time, Location: "US1", StatusCode: 503}}, checks[2:]...)...)
+	s.AddChecks(test.TestID, checks...)
+
+	all, err := c.Tests().Checks(test.TestID, &statuscake.CheckFilter{PageSize: 2})
+	require.NoError(err)
+	assert.Equal(checks, all)
+
+	ranged, err := c.Tests().Checks(test.TestID, &statuscake.CheckFilter{
+		Start:    start.Add(time.Minute),
+		End:      start.Add(3 * time.Minute),
+		Location: "UK1",
+		PageSize: 2,
+	})
+	require.NoError(err)
+	assert.Equal([]*statuscake.Check{checks[1], checks[3], checks[4]}, ranged)
+}
+
+func TestServer_ChecksInOneSecond(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	s := NewServer(testAuth)
+	defer s.Close()
+
+	c, err := s.Client()
+	require.NoError(err)
+
+	test, err := c.Tests().Update(&statuscake.Test{WebsiteName: "Example", WebsiteURL: "https://example.com", TestType: "HTTP"})
+	require.NoError(err)
+
+	start := time.Date(2019, 3, 1, 10, 0, 0, 0, time.UTC)
+	checks := []*statuscake.Check{{Time: start.Add(-time.Minute), Location: "UK1", StatusCode: 200}}
+	// more Checks in one second than the page size
+	for _, location := range []string{"AU1", "DE1", "FR1", "JP1", "UK1"} {
+		checks = append(checks, &statuscake.Check{Time: start, Location: location, StatusCode: 200})
+	}
+	checks = append(checks, &statuscake.Check{Time: start.Add(time.Minute), Location: "UK1", StatusCode: 200})
+	s.AddChecks(test.TestID, checks...)
+
+	all, err := c.Tests().Checks(test.TestID, &statuscake.CheckFilter{PageSize: 2})
+	require.NoError(err)
+	assert.Equal(checks, all)
+
+	fromSecond, err := c.Tests().Checks(test.TestID, &statuscake.CheckFilter{Start: start, PageSize: 2})
+	require.NoError(err)
+	assert.Equal(checks[1:], fromSecond)
+
+	// the first page ends on the crowded second and the next one only returns Checks already seen
+	other, err := c.Tests().Update(&statuscake.Test{WebsiteName: "Other", WebsiteURL: "https://example.org", TestType: "HTTP"})
+	require.NoError(err)
+
+	checks = []*statuscake.Check{
+		{Time: start, Location: "AU1", StatusCode: 200},
+		{Time: start, Location: "UK1", StatusCode: 200},
+		{Time: start.Add(time.Minute), Location: "AU1", StatusCode: 200},
+	}
+	s.AddChecks(other.TestID, checks...)
+
+	all, err = c.Tests().Checks(other.TestID, &statuscake.CheckFilter{PageSize: 2})
+	require.NoError(err)
+	assert.Equal(checks, all)
+}
+
+func TestServer_TestsValidation(t *testing.T) {
+	assert := assert.New(t)
+
+	c := newClient(t)
+
+	_, err := c.Tests().Update(&statuscake.Test{
+		WebsiteURL: "https://example.com",
+		TestType:   "HTTP",
+		CheckRate:  300,
+	})
+	assert.ErrorIs(err, statuscake.ErrValidation)
+
+	var updateErr *statuscake.UpdateError
+	if assert.ErrorAs(err, &updateErr) {
+		assert.Equal([]statuscake.UpdateIssue{{Field: "WebsiteName", Message: "is required"}}, updateErr.Issues)
+	}
+}
+
+func TestServer_Authentication(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	s := NewServer(testAuth)
+	defer s.Close()
+
+	c, err := statuscake.New(statuscake.Auth{Username: "user", Apikey: "wrong"}, statuscake.WithBaseURL(s.URL))
+	require.NoError(err)
+
+	_, err = c.Tests().All()
+	assert.ErrorIs(err, statuscake.ErrUnauthorized)
+}
+
+func TestServer_Ssls(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	c := newClient(t)
+
+	created, err := c.Ssls().Create(&statuscake.PartialSsl{
+		Domain:      "https://example.com",
+		Checkrate:   "3600",
+		AlertAt:     "7,14,30",
+		AlertBroken: true,
+	})
+	require.NoError(err)
+	assert.Equal("1000", created.ID)
+
+	all, err := c.Ssls().All()
+	require.NoError(err)
+	require.Len(all, 1)
+	assert.Equal("https://example.com", all[0].Domain)
+	assert.True(all[0].AlertBroken)
+
+	updated, err := c.Ssls().Update(&statuscake.PartialSsl{
+		ID:        1000,
+		Domain:    "https://example.com",
+		Checkrate: "86400",
+		AlertAt:   "7,14,30",
+	})
+	require.NoError(err)
+	assert.Equal(86400, updated.Checkrate)
+
+	require.NoError(c.Ssls().Delete(created.ID))
+
+	all, err = c.Ssls().All()
+	require.NoError(err)
+	assert.Empty(all)
+}
+
+func TestServer_ContactGroups(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	c := newClient(t)
+
+	cg, err := c.ContactGroups().Create(&statuscake.ContactGroup{
+		GroupName: "ops",
+		Emails:    []string{"a@example.com", "b@example.com"},
+	})
+	require.NoError(err)
+
+	cg.GroupName = "oncall"
+	_, err = c.ContactGroups().Update(cg)
+	require.NoError(err)
+
+	all, err := c.ContactGroups().All()
+	require.NoError(err)
+	require.Len(all, 1)
+	assert.Equal("oncall", all[0].GroupName)
+	assert.Equal([]string{"a@example.com", "b@example.com"}, all[0].Emails)
+
+	require.NoError(c.ContactGroups().Delete(cg.ContactID))
+
+	all, err = c.ContactGroups().All()
+	require.NoError(err)
+	assert.Empty(all)
+}
@@ -1,11 +1,15 @@
 package statuscake
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
 	"reflect"
+	"sort"
 	"strings"
+	"time"
 )
 
 const queryStringTag = "querystring"
@@ -25,12 +29,12 @@ type Test struct {
 	CustomHeader string `json:"CustomHeader" querystring:"CustomHeader"`
 
 	// Use to populate the test with a custom user agent
-	UserAgent string `json:"UserAgent" queryString:"UserAgent"`
+	UserAgent string `json:"UserAgent" querystring:"UserAgent"`
 
 	// Test location, either an IP (for TCP and Ping) or a fully qualified URL for other TestTypes
 	WebsiteURL string `json:"WebsiteURL" querystring:"WebsiteURL"`
 
-	// A Port to use on TCP Tests
+	// A Port to use on TCP, SMTP and SSH Tests, required for them
 	Port int `json:"Port" querystring:"Port"`
 
 	// Contact group ID - deprecated in favor of ContactGroup but still provided in the API detail response
@@ -40,7 +44,7 @@ type Test struct {
 	ContactGroup []string `json:"ContactGroup" querystring:"ContactGroup"`
 
 	// Current status at last test
-	Status string `json:"Status"`
+	Status Status `json:"Status"`
 
 	// 1 Day Uptime
 	Uptime float64 `json:"Uptime"`
@@ -62,23 +66,24 @@ type Test struct {
 	// A Basic Auth User account to use to login
 	BasicUser string `json:"BasicUser" querystring:"BasicUser"`
 
-	// If BasicUser is set then this should be the password for the BasicUser
-	BasicPass string `json:"BasicPass" querystring:"BasicPass"`
+	// If BasicUser is set then this should be the password for the BasicUser.
+	// The API never returns it, so it is only sent when set to keep the current password.
+	BasicPass string `json:"BasicPass" querystring:"BasicPass" querystringoptions:"omitempty"`
 
-	// Set 1 to enable public reporting, 0 to disable
-	Public int `json:"Public" querystring:"Public"`
+	// Enable public reporting
+	Public IntBool `json:"Public" querystring:"Public"`
 
 	// A URL to a image to use for public reporting
 	LogoImage string `json:"LogoImage" querystring:"LogoImage"`
 
-	// Set to 0 to use branding (default) or 1 to disable public reporting branding
-	Branding int `json:"Branding" querystring:"Branding"`
+	// Set to false to use branding (default) or true to disable public reporting branding
+	Branding IntBool `json:"Branding" querystring:"Branding"`
 
 	// Used internally by the statuscake API
 	WebsiteHost string `json:"WebsiteHost" querystring:"WebsiteHost"`
 
-	// Enable virus checking or not. 1 to enable
-	Virus int `json:"Virus" querystring:"Virus"`
+	// Enable virus checking
+	Virus IntBool `json:"Virus" querystring:"Virus"`
 
 	// A string that should either be found or not found.
 	FindString string `json:"FindString" querystring:"FindString"`
@@ -86,11 +91,11 @@ type Test struct {
 	// If the above string should be found to trigger a alert. true will trigger if FindString found
 	DoNotFind bool `json:"DoNotFind" querystring:"DoNotFind"`
 
-	// What type of test type to use. Accepted values are HTTP, TCP, PING
-	TestType string `json:"TestType" querystring:"TestType"`
+	// What type of test type to use. Accepted values are HTTP, HEAD, TCP, DNS, PING, SMTP, SSH and PUSH
+	TestType TestType `json:"TestType" querystring:"TestType"`
 
-	// Use 1 to TURN OFF real browser testing
-	RealBrowser int `json:"RealBrowser" querystring:"RealBrowser"`
+	// Use true to TURN OFF real browser testing
+	RealBrowser IntBool `json:"RealBrowser" querystring:"RealBrowser"`
 
 	// How many minutes to wait before sending an alert
 	TriggerRate int `json:"TriggerRate" querystring:"TriggerRate"`
@@ -101,8 +106,8 @@ type Test struct {
 	// Comma Separated List of StatusCodes to Trigger Error on (on Update will replace, so send full list each time)
 	StatusCodes string `json:"StatusCodes" querystring:"StatusCodes" querystringoptions:"omitempty"`
 
-	// Set to 1 to enable the Cookie Jar. Required for some redirects.
-	UseJar int `json:"UseJar" querystring:"UseJar"`
+	// Enable the Cookie Jar. Required for some redirects.
+	UseJar IntBool `json:"UseJar" querystring:"UseJar"`
 
 	// Raw POST data separated by an ampersand
 	PostRaw string `json:"PostRaw" querystring:"PostRaw"`
@@ -124,6 +129,54 @@ type Test struct {
 
 	// DNS Tests only. IP to compare against WebsiteURL value.
 	DNSIP string `json:"DNSIP" querystring:"DNSIP"`
+
+	// The fields below are only returned by Detail and never sent by Update.
+
+	// Time of the last check. Zero if the Test has never been checked.
+	// It's encoded in JSON in the date format of the API, e.g. "2013-01-20 14:38:18".
+	LastTested time.Time `json:"LastTested"`
+
+	// Node Location ID of the next check
+	NextLocation string `json:"NextLocation"`
+
+	// Whether a check is running
+	Processing bool `json:"Processing"`
+
+	// State of the running check
+	ProcessingState string `json:"ProcessingState"`
+
+	// Server running the check
+	ProcessingOn string `json:"ProcessingOn"`
+
+	// Number of times the Test went down
+	DownTimes int `json:"DownTimes"`
+
+	// Whether the Test is hidden from public reporting as sensitive
+	Sensitive bool `json:"Sensitive"`
+}
+
+// MarshalJSON encodes t like the API does, with LastTested in the date format of the API.
+func (t Test) MarshalJSON() ([]byte, error) {
+	type test Test
+	return json.Marshal(struct {
+		test
+		LastTested apiDateTime `json:"LastTested"`
+	}{test(t), apiDateTime{t.LastTested}})
+}
+
+// UnmarshalJSON decodes a Test returned by the API, with LastTested in the date format of the API.
+func (t *Test) UnmarshalJSON(data []byte) error {
+	type test Test
+	aux := struct {
+		*test
+		LastTested apiDateTime `json:"LastTested"`
+	}{(*test)(t), apiDateTime{t.LastTested}}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	t.LastTested = aux.LastTested.Time
+
+	return nil
 }
 
 // Validate checks if the Test is valid. If it's invalid, it returns a ValidationError with all invalid fields. It returns nil otherwise.
@@ -134,7 +187,12 @@ func (t *Test) Validate() error {
 		e["WebsiteName"] = "is required"
 	}
 
-	if t.WebsiteURL == "" {
+	// statuscake generates the URL PUSH tests are notified at
+	if t.TestType == TestTypePUSH {
+		if t.WebsiteURL != "" {
+			e["WebsiteURL"] = "must not be set for PUSH type tests"
+		}
+	} else if t.WebsiteURL == "" {
 		e["WebsiteURL"] = "is required"
 	}
 
@@ -150,47 +208,63 @@ func (t *Test) Validate() error {
 		e["CheckRate"] = "must be between 0 and 23999"
 	}
 
-	if t.Public < 0 || t.Public > 1 {
-		e["Public"] = "must be 0 or 1"
+	if !t.TestType.is(TestTypes...) {
+		e["TestType"] = "must be HTTP, HEAD, TCP, DNS, PING, SMTP, SSH or PUSH"
 	}
 
-	if t.Virus < 0 || t.Virus > 1 {
-		e["Virus"] = "must be 0 or 1"
+	if t.TriggerRate < 0 || t.TriggerRate > 59 {
+		e["TriggerRate"] = "must be between 0 and 59"
 	}
 
-	if t.TestType != "HTTP" && t.TestType != "TCP" && t.TestType != "PING" && t.TestType != "DNS" {
-		e["TestType"] = "must be HTTP, TCP, DNS or PING"
+	if t.PostRaw != "" && t.TestType != TestTypeHTTP {
+		e["PostRaw"] = "must be HTTP to submit a POST request with PostRaw"
 	}
 
-	if t.RealBrowser < 0 || t.RealBrowser > 1 {
-		e["RealBrowser"] = "must be 0 or 1"
+	if t.PostBody != "" && t.TestType != TestTypeHTTP {
+		e["PostBody"] = "must be HTTP to submit a POST request with PostBody"
 	}
 
-	if t.TriggerRate < 0 || t.TriggerRate > 59 {
-		e["TriggerRate"] = "must be between 0 and 59"
+	if t.FinalEndpoint != "" && t.TestType != TestTypeHTTP {
+		e["FinalEndpoint"] = "must be a Valid URL"
 	}
 
-	if t.PostRaw != "" && t.TestType != "HTTP" {
-		e["PostRaw"] = "must be HTTP to submit a POST request with PostRaw"
+	if t.FindString != "" && !t.TestType.is(TestTypeHTTP, TestTypeHEAD) {
+		e["FindString"] = "must be only used for HTTP and HEAD type tests"
 	}
 
-	if t.PostBody != "" && t.TestType != "HTTP" {
-		e["PostBody"] = "must be HTTP to submit a POST request with PostBody"
+	if t.StatusCodes != "" && !t.TestType.is(TestTypeHTTP, TestTypeHEAD) {
+		e["StatusCodes"] = "must be only used for HTTP and HEAD type tests"
 	}
 
-	if t.FinalEndpoint != "" && t.TestType != "HTTP" {
-		e["FinalEndpoint"] = "must be a Valid URL"
+	if t.FollowRedirect && !t.TestType.is(TestTypeHTTP, TestTypeHEAD) {
+		e["FollowRedirect"] = "must be only used for HTTP and HEAD type tests"
+	}
+
+	if t.TestType.is(TestTypeTCP, TestTypeSMTP, TestTypeSSH) && t.Port == 0 {
+		e["Port"] = "is required"
+	}
+
+	if t.Port < 0 || t.Port > 65535 {
+		e["Port"] = "must be between 0 and 65535"
+	}
+
+	if t.TestType == TestTypePUSH && t.Port != 0 {
+		e["Port"] = "must not be set for PUSH type tests"
 	}
 
-	if t.TestType == "DNS" && t.DNSIP == "" {
+	if t.TestType == TestTypePUSH && t.CheckRate == 0 {
+		e["CheckRate"] = "is required for PUSH type tests"
+	}
+
+	if t.TestType == TestTypeDNS && t.DNSIP == "" {
 		e["DNSIP"] = "is required"
 	}
 
-	if t.DNSServer != "" && t.TestType != "DNS" {
+	if t.DNSServer != "" && t.TestType != TestTypeDNS {
 		e["DNSServer"] = "must be only used for DNS type tests"
 	}
 
-	if t.DNSIP != "" && t.TestType != "DNS" {
+	if t.DNSIP != "" && t.TestType != TestTypeDNS {
 		e["DNSIP"] = "must be only used for DNS type tests"
 	}
 
@@ -254,7 +328,7 @@ func isEmptyValue(v reflect.Value) bool {
 }
 
 func valueToQueryStringValue(v reflect.Value) string {
-	if v.Type().Name() == "bool" {
+	if v.Kind() == reflect.Bool {
 		if v.Bool() {
 			return "1"
 		}
@@ -272,12 +346,47 @@ func valueToQueryStringValue(v reflect.Value) string {
 }
 
 // Tests is a client that implements the `Tests` API.
+//
+// Every method has a variant with the `Context` suffix that accepts a
+// context.Context used to cancel the underlying HTTP request.
 type Tests interface {
 	All() ([]*Test, error)
+	AllContext(context.Context) ([]*Test, error)
 	AllWithFilter(url.Values) ([]*Test, error)
+	AllWithFilterContext(context.Context, url.Values) ([]*Test, error)
+	AllMatching(*TestFilter) ([]*Test, error)
+	AllMatchingContext(context.Context, *TestFilter) ([]*Test, error)
 	Detail(int) (*Test, error)
+	DetailContext(context.Context, int) (*Test, error)
 	Update(*Test) (*Test, error)
+	UpdateContext(context.Context, *Test) (*Test, error)
+	Patch(TestID int, p *TestPatch) error
+	PatchContext(ctx context.Context, TestID int, p *TestPatch) error
 	Delete(TestID int) error
+	DeleteContext(ctx context.Context, TestID int) error
+	Pause(TestID int) error
+	PauseContext(ctx context.Context, TestID int) error
+	Resume(TestID int) error
+	ResumeContext(ctx context.Context, TestID int) error
+	PauseWithTags(tags []string) ([]PauseResult, error)
+	PauseWithTagsContext(ctx context.Context, tags []string) ([]PauseResult, error)
+	ResumeWithTags(tags []string) ([]PauseResult, error)
+	ResumeWithTagsContext(ctx context.Context, tags []string) ([]PauseResult, error)
+	Periods(TestID int) ([]*Period, error)
+	PeriodsContext(ctx context.Context, TestID int) ([]*Period, error)
+	Alerts(TestID int, since time.Time) ([]*Alert, error)
+	AlertsContext(ctx context.Context, TestID int, since time.Time) ([]*Alert, error)
+	Checks(TestID int, f *CheckFilter) ([]*Check, error)
+	ChecksContext(ctx context.Context, TestID int, f *CheckFilter) ([]*Check, error)
+}
+
+// PauseResult is the outcome of pausing or resuming one Test with PauseWithTags or ResumeWithTags.
+type PauseResult struct {
+	TestID      int
+	WebsiteName string
+
+	// Err is nil when the Test has been paused or resumed.
+	Err error
 }
 
 type tests struct {
@@ -291,7 +400,11 @@ func newTests(c apiClient) Tests {
 }
 
 func (tt *tests) All() ([]*Test, error) {
-	resp, err := tt.client.get("/Tests", nil)
+	return tt.AllContext(context.Background())
+}
+
+func (tt *tests) AllContext(ctx context.Context) ([]*Test, error) {
+	resp, err := tt.client.get(ctx, "/Tests", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -304,7 +417,11 @@ func (tt *tests) All() ([]*Test, error) {
 }
 
 func (tt *tests) AllWithFilter(filterOptions url.Values) ([]*Test, error) {
-	resp, err := tt.client.get("/Tests", filterOptions)
+	return tt.AllWithFilterContext(context.Background(), filterOptions)
+}
+
+func (tt *tests) AllWithFilterContext(ctx context.Context, filterOptions url.Values) ([]*Test, error) {
+	resp, err := tt.client.get(ctx, "/Tests", filterOptions)
 	if err != nil {
 		return nil, err
 	}
@@ -316,8 +433,37 @@ func (tt *tests) AllWithFilter(filterOptions url.Values) ([]*Test, error) {
 	return tests, err
 }
 
+func (tt *tests) AllMatching(f *TestFilter) ([]*Test, error) {
+	return tt.AllMatchingContext(context.Background(), f)
+}
+
+func (tt *tests) AllMatchingContext(ctx context.Context, f *TestFilter) ([]*Test, error) {
+	tests, err := tt.AllWithFilterContext(ctx, f.ToURLValues())
+	if err != nil {
+		return nil, err
+	}
+
+	var matching []*Test
+	for _, t := range tests {
+		if f.Match(t) {
+			matching = append(matching, t)
+		}
+	}
+
+	return matching, nil
+}
+
 func (tt *tests) Update(t *Test) (*Test, error) {
-	resp, err := tt.client.put("/Tests/Update", t.ToURLValues())
+	return tt.UpdateContext(context.Background(), t)
+}
+
+func (tt *tests) UpdateContext(ctx context.Context, t *Test) (*Test, error) {
+	if t.TestID == 0 {
+		// a Test without TestID is created, sending it twice would create a duplicate
+		ctx = withoutRetry(ctx)
+	}
+
+	resp, err := tt.client.put(ctx, "/Tests/Update", t.ToURLValues())
 	if err != nil {
 		return nil, err
 	}
@@ -330,7 +476,7 @@ func (tt *tests) Update(t *Test) (*Test, error) {
 	}
 
 	if !ur.Success {
-		return nil, &updateError{Issues: ur.Issues, Message: ur.Message}
+		return nil, newUpdateError(ur.Message, ur.Issues)
 	}
 
 	t2 := *t
@@ -339,8 +485,286 @@ func (tt *tests) Update(t *Test) (*Test, error) {
 	return &t2, err
 }
 
+func (tt *tests) Patch(testID int, p *TestPatch) error {
+	return tt.PatchContext(context.Background(), testID, p)
+}
+
+// PatchContext only sends the fields set in the patch, the other settings of the Test are left untouched.
+func (tt *tests) PatchContext(ctx context.Context, testID int, p *TestPatch) error {
+	if testID == 0 {
+		return fmt.Errorf("%w: TestID is required to patch a Test", ErrValidation)
+	}
+
+	if p == nil {
+		return fmt.Errorf("%w: a TestPatch is required", ErrValidation)
+	}
+
+	v := p.ToURLValues()
+	if len(v) == 0 {
+		return fmt.Errorf("%w: the TestPatch sets no field", ErrValidation)
+	}
+	v.Set("TestID", fmt.Sprint(testID))
+
+	resp, err := tt.client.put(ctx, "/Tests/Update", v)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var ur updateResponse
+	err = json.NewDecoder(resp.Body).Decode(&ur)
+	if err != nil {
+		return err
+	}
+
+	if !ur.Success {
+		return newUpdateError(ur.Message, ur.Issues)
+	}
+
+	return nil
+}
+
+func (tt *tests) Pause(testID int) error {
+	return tt.PauseContext(context.Background(), testID)
+}
+
+func (tt *tests) PauseContext(ctx context.Context, testID int) error {
+	return tt.setPaused(ctx, testID, true)
+}
+
+func (tt *tests) Resume(testID int) error {
+	return tt.ResumeContext(context.Background(), testID)
+}
+
+func (tt *tests) ResumeContext(ctx context.Context, testID int) error {
+	return tt.setPaused(ctx, testID, false)
+}
+
+func (tt *tests) setPaused(ctx context.Context, testID int, paused bool) error {
+	return tt.PatchContext(ctx, testID, &TestPatch{Paused: &paused})
+}
+
+func (tt *tests) PauseWithTags(tags []string) ([]PauseResult, error) {
+	return tt.PauseWithTagsContext(context.Background(), tags)
+}
+
+// PauseWithTagsContext pauses every Test having all the tags. It returns the result for each Test and,
+// when some of them failed, an error joining their errors.
+func (tt *tests) PauseWithTagsContext(ctx context.Context, tags []string) ([]PauseResult, error) {
+	return tt.setPausedWithTags(ctx, tags, true)
+}
+
+func (tt *tests) ResumeWithTags(tags []string) ([]PauseResult, error) {
+	return tt.ResumeWithTagsContext(context.Background(), tags)
+}
+
+// ResumeWithTagsContext is like PauseWithTagsContext but resumes the Tests.
+func (tt *tests) ResumeWithTagsContext(ctx context.Context, tags []string) ([]PauseResult, error) {
+	return tt.setPausedWithTags(ctx, tags, false)
+}
+
+func (tt *tests) setPausedWithTags(ctx context.Context, tags []string, paused bool) ([]PauseResult, error) {
+	if len(tags) == 0 {
+		return nil, fmt.Errorf("%w: at least one tag is required", ErrValidation)
+	}
+
+	tests, err := tt.AllMatchingContext(ctx, &TestFilter{Tags: tags})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]PauseResult, 0, len(tests))
+	var errs []error
+	for _, t := range tests {
+		err := tt.setPaused(ctx, t.TestID, paused)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("test %d: %w", t.TestID, err))
+		}
+
+		results = append(results, PauseResult{TestID: t.TestID, WebsiteName: t.WebsiteName, Err: err})
+	}
+
+	return results, errors.Join(errs...)
+}
+
+func (tt *tests) Periods(testID int) ([]*Period, error) {
+	return tt.PeriodsContext(context.Background(), testID)
+}
+
+func (tt *tests) PeriodsContext(ctx context.Context, testID int) ([]*Period, error) {
+	resp, err := tt.client.get(ctx, "/Tests/Periods", url.Values{"TestID": {fmt.Sprint(testID)}})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var prs []*periodResponse
+	err = json.NewDecoder(resp.Body).Decode(&prs)
+	if err != nil {
+		return nil, err
+	}
+
+	periods := make([]*Period, len(prs))
+	for i, pr := range prs {
+		periods[i] = pr.period()
+	}
+
+	return periods, nil
+}
+
+func (tt *tests) Alerts(testID int, since time.Time) ([]*Alert, error) {
+	return tt.AlertsContext(context.Background(), testID, since)
+}
+
+// AlertsContext returns the Alerts sent for the Test since the given time, every Alert when since is the zero time.
+func (tt *tests) AlertsContext(ctx context.Context, testID int, since time.Time) ([]*Alert, error) {
+	v := url.Values{"TestID": {fmt.Sprint(testID)}}
+	if !since.IsZero() {
+		v.Set("Since", fmt.Sprint(since.Unix()))
+	}
+
+	resp, err := tt.client.get(ctx, "/Alerts", v)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var ars []*alertResponse
+	err = json.NewDecoder(resp.Body).Decode(&ars)
+	if err != nil {
+		return nil, err
+	}
+
+	alerts := make([]*Alert, 0, len(ars))
+	for _, ar := range ars {
+		a := ar.alert()
+		// Since is applied again in case the API returned older Alerts
+		if a.Triggered.Before(since.Truncate(time.Second)) {
+			continue
+		}
+
+		alerts = append(alerts, a)
+	}
+
+	return alerts, nil
+}
+
+func (tt *tests) Checks(testID int, f *CheckFilter) ([]*Check, error) {
+	return tt.ChecksContext(context.Background(), testID, f)
+}
+
+// ChecksContext returns the Checks of the Test selected by the filter, oldest first.
+// It requests pages of Checks until the time range is exhausted.
+func (tt *tests) ChecksContext(ctx context.Context, testID int, f *CheckFilter) ([]*Check, error) {
+	if f == nil {
+		f = &CheckFilter{}
+	}
+
+	end := f.End
+	if end.IsZero() {
+		end = time.Now()
+	}
+
+	limit := f.pageSize()
+	v := url.Values{
+		"TestID": {fmt.Sprint(testID)},
+		"Fields": {"status,location,time,performance"},
+		"End":    {fmt.Sprint(end.Unix())},
+	}
+
+	var start int64
+	if !f.Start.IsZero() {
+		start = f.Start.Unix()
+		v.Set("Start", fmt.Sprint(start))
+	}
+
+	var checks []*Check
+	// how many times each Check of the second the page starts at was returned by the previous page
+	returned := make(map[checkResponse]int)
+	pageLimit := limit
+	for {
+		v.Set("Limit", fmt.Sprint(pageLimit))
+		page, err := tt.checksPage(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+
+		added := 0
+		for _, cr := range page {
+			if returned[*cr] > 0 {
+				returned[*cr]--
+				continue
+			}
+
+			if cr.Time < start || cr.Time > end.Unix() {
+				continue
+			}
+			added++
+
+			if f.Location == "" || cr.Location == f.Location {
+				checks = append(checks, cr.check())
+			}
+		}
+
+		if len(page) < pageLimit {
+			return checks, nil
+		}
+
+		last := page[len(page)-1].Time
+		if last == start {
+			// the whole page is in one second, the API can't start after some Checks of a second
+			// so the same second is requested again with a larger page to get the remaining ones,
+			// even if this page only returned Checks already seen
+			pageLimit *= 2
+		} else if added == 0 {
+			return checks, nil
+		} else {
+			start = last
+			pageLimit = limit
+		}
+
+		returned = make(map[checkResponse]int)
+		for _, cr := range page {
+			if cr.Time == start {
+				returned[*cr]++
+			}
+		}
+
+		v.Set("Start", fmt.Sprint(start))
+	}
+}
+
+// checksPage returns a page of Checks sorted by time, then location.
+func (tt *tests) checksPage(ctx context.Context, v url.Values) (checksResponse, error) {
+	resp, err := tt.client.get(ctx, "/Tests/Checks", v)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var page checksResponse
+	err = json.NewDecoder(resp.Body).Decode(&page)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(page, func(i, j int) bool {
+		if page[i].Time != page[j].Time {
+			return page[i].Time < page[j].Time
+		}
+
+		return page[i].Location < page[j].Location
+	})
+
+	return page, nil
+}
+
 func (tt *tests) Delete(testID int) error {
-	resp, err := tt.client.delete("/Tests/Details", url.Values{"TestID": {fmt.Sprint(testID)}})
+	return tt.DeleteContext(context.Background(), testID)
+}
+
+func (tt *tests) DeleteContext(ctx context.Context, testID int) error {
+	resp, err := tt.client.delete(ctx, "/Tests/Details", url.Values{"TestID": {fmt.Sprint(testID)}})
 	if err != nil {
 		return err
 	}
@@ -360,7 +784,11 @@ func (tt *tests) Delete(testID int) error {
 }
 
 func (tt *tests) Detail(testID int) (*Test, error) {
-	resp, err := tt.client.get("/Tests/Details", url.Values{"TestID": {fmt.Sprint(testID)}})
+	return tt.DetailContext(context.Background(), testID)
+}
+
+func (tt *tests) DetailContext(ctx context.Context, testID int) (*Test, error) {
+	resp, err := tt.client.get(ctx, "/Tests/Details", url.Values{"TestID": {fmt.Sprint(testID)}})
 	if err != nil {
 		return nil, err
 	}
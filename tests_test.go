@@ -2,7 +2,9 @@ package statuscake
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
@@ -11,6 +13,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -23,10 +26,7 @@ func TestTest_Validate(t *testing.T) {
 	test := &Test{
 		Timeout:      200,
 		Confirmation: 100,
-		Public:       200,
-		Virus:        200,
 		TestType:     "FTP",
-		RealBrowser:  100,
 		TriggerRate:  100,
 		CheckRate:    100000,
 		CustomHeader: "here be dragons",
@@ -45,10 +45,7 @@ func TestTest_Validate(t *testing.T) {
 	assert.Contains(message, "Timeout must be 0 or between 6 and 99")
 	assert.Contains(message, "Confirmation must be between 0 and 9")
 	assert.Contains(message, "CheckRate must be between 0 and 23999")
-	assert.Contains(message, "Public must be 0 or 1")
-	assert.Contains(message, "Virus must be 0 or 1")
-	assert.Contains(message, "TestType must be HTTP, TCP, DNS or PING")
-	assert.Contains(message, "RealBrowser must be 0 or 1")
+	assert.Contains(message, "TestType must be HTTP, HEAD, TCP, DNS, PING, SMTP, SSH or PUSH")
 	assert.Contains(message, "TriggerRate must be between 0 and 59")
 	assert.Contains(message, "CustomHeader must be provided as json string")
 	assert.Contains(message, "DNSServer must be only used for DNS type tests")
@@ -56,10 +53,10 @@ func TestTest_Validate(t *testing.T) {
 
 	test.Timeout = 10
 	test.Confirmation = 2
-	test.Public = 1
-	test.Virus = 1
-	test.TestType = "HTTP"
-	test.RealBrowser = 1
+	test.Public = true
+	test.Virus = true
+	test.TestType = TestTypeHTTP
+	test.RealBrowser = true
 	test.TriggerRate = 50
 	test.CheckRate = 10
 	test.WebsiteName = "Foo"
@@ -91,6 +88,55 @@ func TestTest_Validate(t *testing.T) {
 	assert.Nil(err2)
 }
 
+func TestTest_ValidateTestTypes(t *testing.T) {
+	cases := []struct {
+		name   string
+		test   Test
+		issues ValidationError
+	}{
+		{"HTTP", Test{TestType: "HTTP", FindString: "ok", StatusCodes: "500", FollowRedirect: true}, nil},
+		{"HEAD", Test{TestType: "HEAD", FindString: "ok", StatusCodes: "500", FollowRedirect: true}, nil},
+		{"TCP", Test{TestType: "TCP", Port: 443}, nil},
+		{"TCP without Port", Test{TestType: "TCP"}, ValidationError{"Port": "is required"}},
+		{"SMTP", Test{TestType: "SMTP", Port: 25}, nil},
+		{"SMTP without Port", Test{TestType: "SMTP"}, ValidationError{"Port": "is required"}},
+		{"SSH", Test{TestType: "SSH", Port: 22}, nil},
+		{"SSH with invalid Port", Test{TestType: "SSH", Port: 70000}, ValidationError{"Port": "must be between 0 and 65535"}},
+		{"HTTP with negative Port", Test{TestType: "HTTP", Port: -1}, ValidationError{"Port": "must be between 0 and 65535"}},
+		{"PING", Test{TestType: "PING"}, nil},
+		{"PING with HTTP fields", Test{TestType: "PING", FindString: "ok", StatusCodes: "500", FollowRedirect: true}, ValidationError{
+			"FindString":     "must be only used for HTTP and HEAD type tests",
+			"StatusCodes":    "must be only used for HTTP and HEAD type tests",
+			"FollowRedirect": "must be only used for HTTP and HEAD type tests",
+		}},
+		{"PUSH", Test{TestType: "PUSH", CheckRate: 300}, nil},
+		{"PUSH without CheckRate", Test{TestType: "PUSH"}, ValidationError{"CheckRate": "is required for PUSH type tests"}},
+		{"PUSH with rejected fields", Test{TestType: "PUSH", CheckRate: 300, WebsiteURL: "example.com", Port: 443, FindString: "ok"}, ValidationError{
+			"WebsiteURL": "must not be set for PUSH type tests",
+			"Port":       "must not be set for PUSH type tests",
+			"FindString": "must be only used for HTTP and HEAD type tests",
+		}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			test := c.test
+			test.WebsiteName = "Foo"
+			if test.TestType != "PUSH" {
+				test.WebsiteURL = "example.com"
+			}
+
+			err := test.Validate()
+			if c.issues == nil {
+				assert.Nil(t, err)
+				return
+			}
+
+			assert.Equal(t, c.issues, err)
+		})
+	}
+}
+
 func TestTest_ToURLValues(t *testing.T) {
 	assert := assert.New(t)
 
@@ -108,15 +154,15 @@ func TestTest_ToURLValues(t *testing.T) {
 		CheckRate:      500,
 		BasicUser:      "myuser",
 		BasicPass:      "mypass",
-		Public:         1,
+		Public:         true,
 		LogoImage:      "http://example.com/logo.jpg",
-		Branding:       1,
+		Branding:       true,
 		WebsiteHost:    "hoster",
-		Virus:          1,
+		Virus:          true,
 		FindString:     "hello",
 		DoNotFind:      true,
 		TestType:       "HTTP",
-		RealBrowser:    1,
+		RealBrowser:    true,
 		TriggerRate:    50,
 		TestTags:       []string{"tag1", "tag2"},
 		StatusCodes:    "500",
@@ -132,6 +178,7 @@ func TestTest_ToURLValues(t *testing.T) {
 		"WebsiteName":    {"Foo Bar"},
 		"WebsiteURL":     {"http://example.com"},
 		"CustomHeader":   {`{"some":{"json": ["value"]}}`},
+		"UserAgent":      {""},
 		"Port":           {"3000"},
 		"NodeLocations":  {"foo,bar"},
 		"Timeout":        {"11"},
@@ -174,6 +221,11 @@ func TestTest_ToURLValues(t *testing.T) {
 	delete(expected, "StatusCodes")
 
 	assert.Equal(expected.Encode(), test.ToURLValues().Encode())
+
+	test.BasicPass = ""
+	delete(expected, "BasicPass")
+
+	assert.Equal(expected.Encode(), test.ToURLValues().Encode())
 }
 
 func TestTests_All(t *testing.T) {
@@ -218,6 +270,43 @@ func TestTests_All(t *testing.T) {
 	assert.Equal(expectedTest, tests[1])
 }
 
+func TestTests_AllWithLastTested(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	c := &fakeAPIClient{
+		fixture: "tests_all_last_tested.json",
+	}
+	tt := newTests(c)
+	tests, err := tt.All()
+	require.Nil(err)
+	require.Len(tests, 2)
+
+	assert.Equal(time.Date(2013, 1, 20, 14, 38, 18, 0, time.UTC), tests[0].LastTested)
+	assert.True(tests[1].LastTested.IsZero())
+	assert.Equal("www 1", tests[0].WebsiteName)
+	assert.Equal(StatusDown, tests[1].Status)
+}
+
+func TestTests_AllContext(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	c := &fakeAPIClient{
+		fixture: "tests_all_ok.json",
+	}
+	tt := newTests(c)
+
+	type ctxKey struct{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "foo")
+
+	tests, err := tt.AllContext(ctx)
+	require.Nil(err)
+
+	assert.Equal(ctx, c.sentRequestContext)
+	assert.Len(tests, 2)
+}
+
 func TestTests_AllWithFilter(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
@@ -251,6 +340,234 @@ func TestTests_AllWithFilter(t *testing.T) {
 	assert.Equal(expectedTest, tests[0])
 }
 
+func TestTests_AllMatching(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	c := &fakeAPIClient{
+		fixture: "tests_all_ok.json",
+	}
+
+	tt := newTests(c)
+	tests, err := tt.AllMatching(&TestFilter{Status: "Up", TestType: "HTTP"})
+	require.Nil(err)
+
+	assert.Equal("/Tests", c.sentRequestPath)
+	assert.Equal("GET", c.sentRequestMethod)
+	assert.Equal(url.Values{}, c.sentRequestValues)
+	require.Len(tests, 1)
+	assert.Equal(100, tests[0].TestID)
+
+	tests, err = tt.AllMatching(&TestFilter{Tags: []string{"test1"}, Status: "Up"})
+	require.Nil(err)
+
+	assert.Equal(url.Values{"tags": {"test1"}}, c.sentRequestValues)
+	assert.Empty(tests)
+
+	tests, err = tt.AllMatching(nil)
+	require.Nil(err)
+
+	assert.Equal(url.Values{}, c.sentRequestValues)
+	assert.Len(tests, 2)
+}
+
+func TestTests_PauseResume(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	c := &fakeAPIClient{
+		fixture: "tests_update_ok.json",
+	}
+
+	tt := newTests(c)
+	require.Nil(tt.Pause(1234))
+
+	assert.Equal("/Tests/Update", c.sentRequestPath)
+	assert.Equal("PUT", c.sentRequestMethod)
+	assert.Equal(url.Values{"TestID": {"1234"}, "Paused": {"1"}}, c.sentRequestValues)
+
+	require.Nil(tt.Resume(1234))
+	assert.Equal(url.Values{"TestID": {"1234"}, "Paused": {"0"}}, c.sentRequestValues)
+}
+
+func TestTests_Patch(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	c := &fakeAPIClient{
+		fixture: "tests_update_ok.json",
+	}
+
+	tt := newTests(c)
+	err := tt.Patch(1234, &TestPatch{
+		FindString:     Ptr(""),
+		FollowRedirect: Ptr(false),
+	})
+	require.Nil(err)
+
+	assert.Equal("/Tests/Update", c.sentRequestPath)
+	assert.Equal("PUT", c.sentRequestMethod)
+	assert.Equal(url.Values{"TestID": {"1234"}, "FindString": {""}, "FollowRedirect": {"0"}}, c.sentRequestValues)
+
+	c.sentRequestMethod = ""
+	err = tt.Patch(0, &TestPatch{FindString: Ptr("foo")})
+	assert.ErrorIs(err, ErrValidation)
+	assert.Empty(c.sentRequestMethod)
+
+	err = tt.Patch(1234, nil)
+	assert.ErrorIs(err, ErrValidation)
+	assert.Empty(c.sentRequestMethod)
+
+	err = tt.Patch(1234, &TestPatch{})
+	assert.ErrorIs(err, ErrValidation)
+	assert.Empty(c.sentRequestMethod)
+
+	c.fixture = "tests_update_error.json"
+	err = tt.Patch(1234, &TestPatch{CheckRate: Ptr(-1)})
+	assert.IsType(&UpdateError{}, err)
+}
+
+func TestTests_Pause_Error(t *testing.T) {
+	assert := assert.New(t)
+
+	c := &fakeAPIClient{
+		fixture: "tests_update_error.json",
+	}
+
+	err := newTests(c).Pause(1234)
+	assert.IsType(&UpdateError{}, err)
+	assert.ErrorIs(err, ErrValidation)
+}
+
+func TestTests_PauseWithTags_NoTags(t *testing.T) {
+	assert := assert.New(t)
+
+	c := &fakeAPIClient{
+		fixture: "tests_all_ok.json",
+	}
+
+	results, err := newTests(c).PauseWithTags(nil)
+	assert.ErrorIs(err, ErrValidation)
+	assert.Nil(results)
+	assert.Empty(c.sentRequestMethod)
+}
+
+func TestTests_Periods(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	c := &fakeAPIClient{
+		fixture: "tests_periods_ok.json",
+	}
+
+	periods, err := newTests(c).Periods(1234)
+	require.Nil(err)
+
+	assert.Equal("/Tests/Periods", c.sentRequestPath)
+	assert.Equal("GET", c.sentRequestMethod)
+	assert.Equal(url.Values{"TestID": {"1234"}}, c.sentRequestValues)
+
+	require.Len(periods, 2)
+
+	assert.Equal(StatusUp, periods[0].Status)
+	assert.Equal(time.Date(2019, 3, 1, 10, 15, 0, 0, time.UTC), periods[0].Start)
+	assert.True(periods[0].Ongoing())
+	assert.Equal(time.Duration(0), periods[0].Duration)
+
+	assert.Equal(&Period{
+		Status:     "Down",
+		Start:      time.Date(2019, 3, 1, 10, 0, 0, 0, time.UTC),
+		End:        time.Date(2019, 3, 1, 10, 15, 0, 0, time.UTC),
+		Duration:   15 * time.Minute,
+		Additional: "Timeout",
+	}, periods[1])
+	assert.False(periods[1].Ongoing())
+}
+
+func TestTests_Alerts(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	c := &fakeAPIClient{
+		fixture: "tests_alerts_ok.json",
+	}
+
+	tt := newTests(c)
+	alerts, err := tt.Alerts(1234, time.Time{})
+	require.Nil(err)
+
+	assert.Equal("/Alerts", c.sentRequestPath)
+	assert.Equal("GET", c.sentRequestMethod)
+	assert.Equal(url.Values{"TestID": {"1234"}}, c.sentRequestValues)
+
+	require.Len(alerts, 3)
+	assert.Equal(&Alert{
+		TestID:        1234,
+		Triggered:     time.Date(2019, 3, 1, 10, 15, 0, 0, time.UTC),
+		Status:        "Up",
+		StatusCode:    200,
+		ContactGroups: []string{"5", "6"},
+	}, alerts[0])
+	assert.Nil(alerts[1].ContactGroups)
+
+	since := time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC)
+	alerts, err = tt.Alerts(1234, since)
+	require.Nil(err)
+
+	assert.Equal(url.Values{"TestID": {"1234"}, "Since": {"1551398400"}}, c.sentRequestValues)
+	require.Len(alerts, 2)
+	assert.Equal(503, alerts[1].StatusCode)
+}
+
+func TestTests_Checks(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	c := &fakeAPIClient{
+		fixture: "tests_checks_ok.json",
+	}
+
+	tt := newTests(c)
+	end := time.Date(2019, 3, 2, 0, 0, 0, 0, time.UTC)
+	checks, err := tt.Checks(1234, &CheckFilter{End: end})
+	require.Nil(err)
+
+	assert.Equal("/Tests/Checks", c.sentRequestPath)
+	assert.Equal("GET", c.sentRequestMethod)
+	assert.Equal(url.Values{
+		"TestID": {"1234"},
+		"Fields": {"status,location,time,performance"},
+		"End":    {"1551484800"},
+		"Limit":  {"1000"},
+	}, c.sentRequestValues)
+
+	assert.Equal([]*Check{
+		{
+			Time:        time.Date(2019, 3, 1, 10, 0, 0, 0, time.UTC),
+			Location:    "US1",
+			StatusCode:  503,
+			Performance: 2048 * time.Millisecond,
+		},
+		{
+			Time:        time.Date(2019, 3, 1, 10, 1, 0, 0, time.UTC),
+			Location:    "UK1",
+			StatusCode:  200,
+			Performance: 156 * time.Millisecond,
+		},
+	}, checks)
+
+	checks, err = tt.Checks(1234, &CheckFilter{
+		Start:    time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC),
+		End:      end,
+		Location: "UK1",
+	})
+	require.Nil(err)
+
+	assert.Equal("1551398400", c.sentRequestValues.Get("Start"))
+	require.Len(checks, 1)
+	assert.Equal("UK1", checks[0].Location)
+}
+
 func TestTests_Update_OK(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
@@ -292,8 +609,9 @@ func TestTests_Update_Error(t *testing.T) {
 	assert.Nil(test2)
 
 	require.NotNil(err)
-	assert.IsType(&updateError{}, err)
+	assert.IsType(&UpdateError{}, err)
 	assert.Contains(err.Error(), "issue a")
+	assert.Equal("issue c", err.(*UpdateError).Fields()["CheckRate"])
 }
 
 func TestTests_Update_ErrorWithSliceOfIssues(t *testing.T) {
@@ -313,7 +631,7 @@ func TestTests_Update_ErrorWithSliceOfIssues(t *testing.T) {
 	assert.Nil(test2)
 
 	require.NotNil(err)
-	assert.IsType(&updateError{}, err)
+	assert.IsType(&UpdateError{}, err)
 	assert.Equal("Required Data is Missing., hello, world", err.Error())
 }
 
@@ -365,13 +683,13 @@ func TestTests_Detail_OK(t *testing.T) {
 	assert.Equal(url.Values{"TestID": {"1234"}}, c.sentRequestValues)
 
 	assert.Equal(test.TestID, 6735)
-	assert.Equal(test.TestType, "HTTP")
+	assert.Equal(test.TestType, TestTypeHTTP)
 	assert.Equal(test.Paused, false)
 	assert.Equal(test.WebsiteName, "NL")
 	assert.Equal(test.CustomHeader, `{"some":{"json": ["value"]}}`)
 	assert.Equal(test.UserAgent, "product/version (comment)")
 	assert.Equal(test.ContactGroup, []string{"536"})
-	assert.Equal(test.Status, "Up")
+	assert.Equal(test.Status, StatusUp)
 	assert.Equal(test.Uptime, 0.0)
 	assert.Equal(test.CheckRate, 60)
 	assert.Equal(test.Timeout, 40)
@@ -380,6 +698,149 @@ func TestTests_Detail_OK(t *testing.T) {
 	assert.Equal(test.FindString, "")
 	assert.Equal(test.DoNotFind, false)
 	assert.Equal(test.NodeLocations, []string{"foo", "bar"})
+	assert.Equal(test.PingURL, "http://example.com/ping")
+	assert.Equal(test.BasicUser, "user")
+	assert.Equal(test.Public, IntBool(true))
+	assert.Equal(test.Branding, IntBool(false))
+	assert.Equal(test.Virus, IntBool(true))
+	assert.Equal(test.RealBrowser, IntBool(true))
+	assert.Equal(test.LastTested, time.Date(2013, 1, 20, 14, 38, 18, 0, time.UTC))
+	assert.Equal(test.NextLocation, "USNY")
+	assert.Equal(test.Processing, false)
+	assert.Equal(test.ProcessingState, "Pretest")
+	assert.Equal(test.ProcessingOn, "dalas.localdomain")
+	assert.Equal(test.DownTimes, 3)
+	assert.Equal(test.Sensitive, true)
+}
+
+func TestTests_DetailUpdateRoundTrip(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	c := &fakeAPIClient{
+		fixture: "tests_detail_ok.json",
+	}
+	tt := newTests(c)
+
+	test, err := tt.Detail(6735)
+	require.Nil(err)
+
+	c.fixture = "tests_update_ok.json"
+	_, err = tt.Update(test)
+	require.Nil(err)
+
+	// every value sent is the one returned by Detail, BasicPass is not sent to keep the current one
+	assert.Equal(url.Values{
+		"TestID":         {"6735"},
+		"Paused":         {"0"},
+		"WebsiteName":    {"NL"},
+		"CustomHeader":   {`{"some":{"json": ["value"]}}`},
+		"UserAgent":      {"product/version (comment)"},
+		"WebsiteURL":     {""},
+		"Port":           {"0"},
+		"ContactGroup":   {"536"},
+		"NodeLocations":  {"foo,bar"},
+		"Timeout":        {"40"},
+		"PingURL":        {"http://example.com/ping"},
+		"Confirmation":   {"0"},
+		"CheckRate":      {"60"},
+		"BasicUser":      {"user"},
+		"Public":         {"1"},
+		"LogoImage":      {""},
+		"Branding":       {"0"},
+		"WebsiteHost":    {"Various"},
+		"Virus":          {"1"},
+		"FindString":     {""},
+		"DoNotFind":      {"0"},
+		"TestType":       {"HTTP"},
+		"RealBrowser":    {"1"},
+		"TriggerRate":    {"0"},
+		"TestTags":       {""},
+		"UseJar":         {"0"},
+		"PostRaw":        {""},
+		"PostBody":       {""},
+		"FinalEndpoint":  {""},
+		"EnableSSLAlert": {"0"},
+		"FollowRedirect": {"0"},
+		"DNSServer":      {""},
+		"DNSIP":          {""},
+	}, c.sentRequestValues)
+}
+
+func TestTests_DetailUpdateRoundTrip_APIPayload(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	b, err := ioutil.ReadFile(filepath.Join("fixtures", "tests_detail_api.json"))
+	require.Nil(err)
+
+	var payload map[string]interface{}
+	require.Nil(json.Unmarshal(b, &payload))
+
+	c := &fakeAPIClient{
+		fixture: "tests_detail_api.json",
+	}
+	tt := newTests(c)
+
+	test, err := tt.Detail(6735)
+	require.Nil(err)
+
+	c.fixture = "tests_update_ok.json"
+	_, err = tt.Update(test)
+	require.Nil(err)
+
+	// the names of the updated fields that differ in the detail response
+	renamed := map[string]string{
+		"URI":              "WebsiteURL",
+		"Tags":             "TestTags",
+		"EnableSSLWarning": "EnableSSLAlert",
+	}
+
+	sent := c.sentRequestValues
+	for key, value := range payload {
+		field := key
+		if r, ok := renamed[key]; ok {
+			field = r
+		}
+
+		if _, ok := sent[field]; !ok {
+			// read-only fields like Status or LastTested are not sent
+			continue
+		}
+
+		var expected string
+		switch v := value.(type) {
+		case bool:
+			expected = "0"
+			if v {
+				expected = "1"
+			}
+		case []interface{}:
+			s := make([]string, len(v))
+			for i, e := range v {
+				s[i] = fmt.Sprint(e)
+			}
+			expected = strings.Join(s, ",")
+		default:
+			expected = fmt.Sprint(v)
+		}
+
+		assert.Equal(expected, sent.Get(field), key)
+	}
+
+	assert.Equal("536,537", sent.Get("ContactGroup"))
+
+	// every updatable field of the payload is sent back
+	for _, key := range []string{"TestID", "Paused", "WebsiteName", "URI", "CustomHeader", "UserAgent", "CheckRate", "Timeout",
+		"LogoImage", "Confirmation", "WebsiteHost", "NodeLocations", "FindString", "DoNotFind", "Port", "TriggerRate", "UseJar",
+		"PostRaw", "PostBody", "FinalEndpoint", "EnableSSLWarning", "FollowRedirect", "DNSServer", "DNSIP", "StatusCodes", "Tags"} {
+		field := key
+		if r, ok := renamed[key]; ok {
+			field = r
+		}
+
+		assert.Contains(sent, field, key)
+	}
 }
 
 func TestTests_DNS_Detail_OK(t *testing.T) {
@@ -399,10 +860,10 @@ func TestTests_DNS_Detail_OK(t *testing.T) {
 	assert.Equal(url.Values{"TestID": {"1337"}}, c.sentRequestValues)
 
 	assert.Equal(test.TestID, 1337)
-	assert.Equal(test.TestType, "DNS")
+	assert.Equal(test.TestType, TestTypeDNS)
 	assert.Equal(test.Paused, false)
 	assert.Equal(test.WebsiteName, "A DNS test")
-	assert.Equal(test.Status, "Up")
+	assert.Equal(test.Status, StatusUp)
 	assert.Equal(test.Uptime, 100.0)
 	assert.Equal(test.CheckRate, 300)
 	assert.Equal(test.DNSServer, "1.1.1.1")
@@ -442,25 +903,27 @@ func TestTests_DNS_Create_OK(t *testing.T) {
 }
 
 type fakeAPIClient struct {
-	sentRequestPath   string
-	sentRequestMethod string
-	sentRequestValues url.Values
-	fixture           string
+	sentRequestContext context.Context
+	sentRequestPath    string
+	sentRequestMethod  string
+	sentRequestValues  url.Values
+	fixture            string
 }
 
-func (c *fakeAPIClient) put(path string, v url.Values) (*http.Response, error) {
-	return c.all("PUT", path, v)
+func (c *fakeAPIClient) put(ctx context.Context, path string, v url.Values) (*http.Response, error) {
+	return c.all(ctx, "PUT", path, v)
 }
 
-func (c *fakeAPIClient) delete(path string, v url.Values) (*http.Response, error) {
-	return c.all("DELETE", path, v)
+func (c *fakeAPIClient) delete(ctx context.Context, path string, v url.Values) (*http.Response, error) {
+	return c.all(ctx, "DELETE", path, v)
 }
 
-func (c *fakeAPIClient) get(path string, v url.Values) (*http.Response, error) {
-	return c.all("GET", path, v)
+func (c *fakeAPIClient) get(ctx context.Context, path string, v url.Values) (*http.Response, error) {
+	return c.all(ctx, "GET", path, v)
 }
 
-func (c *fakeAPIClient) all(method string, path string, v url.Values) (*http.Response, error) {
+func (c *fakeAPIClient) all(ctx context.Context, method string, path string, v url.Values) (*http.Response, error) {
+	c.sentRequestContext = ctx
 	c.sentRequestMethod = method
 	c.sentRequestPath = path
 	c.sentRequestValues = v
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"time"
 )
 
 type jsonNumberString string
@@ -59,3 +60,106 @@ func truncate(b []byte, max int) []byte {
 	}
 	return b
 }
+
+// TestType is the kind of check run by a Test.
+type TestType string
+
+// Test types accepted by the API.
+const (
+	TestTypeHTTP TestType = "HTTP"
+	TestTypeHEAD TestType = "HEAD"
+	TestTypeTCP  TestType = "TCP"
+	TestTypeDNS  TestType = "DNS"
+	TestTypePING TestType = "PING"
+	TestTypeSMTP TestType = "SMTP"
+	TestTypeSSH  TestType = "SSH"
+	TestTypePUSH TestType = "PUSH"
+)
+
+// TestTypes lists every TestType accepted by the API.
+var TestTypes = []TestType{TestTypeHTTP, TestTypeHEAD, TestTypeTCP, TestTypeDNS, TestTypePING, TestTypeSMTP, TestTypeSSH, TestTypePUSH}
+
+// is reports whether t is one of types.
+func (t TestType) is(types ...TestType) bool {
+	for _, tt := range types {
+		if t == tt {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Status is the state of a Test at its last check.
+type Status string
+
+// Statuses returned by the API.
+const (
+	StatusUp   Status = "Up"
+	StatusDown Status = "Down"
+)
+
+// IntBool is a bool the API sends and receives as 0 or 1.
+type IntBool bool
+
+// MarshalJSON encodes b as 0 or 1.
+func (b IntBool) MarshalJSON() ([]byte, error) {
+	if b {
+		return []byte("1"), nil
+	}
+
+	return []byte("0"), nil
+}
+
+// UnmarshalJSON decodes 0, 1, true or false, quoted or not. null leaves b unchanged.
+func (b *IntBool) UnmarshalJSON(data []byte) error {
+	s := string(bytes.Trim(data, `"`))
+	if s == "null" {
+		return nil
+	}
+
+	v, err := strconv.ParseBool(s)
+	if err != nil {
+		return fmt.Errorf("cannot unmarshal value that is neither 0, 1, true nor false: %s", truncate(data, 30))
+	}
+
+	*b = IntBool(v)
+
+	return nil
+}
+
+// dateTimeLayout is the layout of the dates returned by the API, in UTC.
+const dateTimeLayout = "2006-01-02 15:04:05"
+
+// apiDateTime is a time the API sends as a dateTimeLayout string, empty when unknown.
+type apiDateTime struct {
+	time.Time
+}
+
+func (t apiDateTime) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte(`""`), nil
+	}
+
+	return json.Marshal(t.UTC().Format(dateTimeLayout))
+}
+
+func (t *apiDateTime) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	t.Time = time.Time{}
+	if s == "" {
+		return nil
+	}
+
+	v, err := time.ParseInLocation(dateTimeLayout, s, time.UTC)
+	if err != nil {
+		return err
+	}
+	t.Time = v
+
+	return nil
+}
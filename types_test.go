@@ -3,6 +3,7 @@ package statuscake
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -74,3 +75,98 @@ func TestTruncate(t *testing.T) {
 	assert.Equal([]byte("foo..."), truncate([]byte("foobarbaz"), 6))
 	assert.Equal([]byte("foo..."), truncate([]byte("foobarbaz"), 1))
 }
+
+func TestIntBoolMarshal(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := json.Marshal(struct{ A, B IntBool }{A: true})
+
+	assert.Equal(`{"A":1,"B":0}`, string(b))
+	assert.NoError(err)
+}
+
+func TestIntBoolUnmarshal(t *testing.T) {
+	assert := assert.New(t)
+
+	for input, expected := range map[string]IntBool{
+		`1`:       true,
+		`0`:       false,
+		`"1"`:     true,
+		`"0"`:     false,
+		`true`:    true,
+		`false`:   false,
+		`"true"`:  true,
+		`"false"`: false,
+	} {
+		var b IntBool
+		assert.NoError(json.Unmarshal([]byte(input), &b), input)
+		assert.Equal(expected, b, input)
+	}
+
+	b := IntBool(true)
+	assert.NoError(json.Unmarshal([]byte(`null`), &b))
+	assert.Equal(IntBool(true), b)
+
+	assert.Error(json.Unmarshal([]byte(`2`), &b))
+	assert.Error(json.Unmarshal([]byte(`"yes"`), &b))
+}
+
+func TestTestJSONWireCompatibility(t *testing.T) {
+	assert := assert.New(t)
+
+	input := `{"TestType":"HTTP","Status":"Down","Public":1,"Virus":0,"RealBrowser":1,"UseJar":0,"Branding":0}`
+
+	var test Test
+	assert.NoError(json.Unmarshal([]byte(input), &test))
+	assert.Equal(TestTypeHTTP, test.TestType)
+	assert.Equal(StatusDown, test.Status)
+	assert.Equal(IntBool(true), test.Public)
+	assert.Equal(IntBool(false), test.Virus)
+	assert.Equal(IntBool(true), test.RealBrowser)
+
+	b, err := json.Marshal(test)
+	assert.NoError(err)
+
+	var output map[string]interface{}
+	assert.NoError(json.Unmarshal(b, &output))
+	assert.Equal("HTTP", output["TestType"])
+	assert.Equal("Down", output["Status"])
+	assert.Equal(float64(1), output["Public"])
+	assert.Equal(float64(0), output["Virus"])
+	assert.Equal(float64(1), output["RealBrowser"])
+	assert.Equal(float64(0), output["UseJar"])
+}
+
+func TestAPIDateTimeUnmarshal(t *testing.T) {
+	assert := assert.New(t)
+
+	var v struct{ A, B apiDateTime }
+	assert.NoError(json.Unmarshal([]byte(`{"A":"2013-01-20 14:38:18","B":""}`), &v))
+	assert.Equal(time.Date(2013, 1, 20, 14, 38, 18, 0, time.UTC), v.A.Time)
+	assert.True(v.B.IsZero())
+
+	b, err := json.Marshal(v)
+	assert.NoError(err)
+	assert.Equal(`{"A":"2013-01-20 14:38:18","B":""}`, string(b))
+
+	assert.Error(json.Unmarshal([]byte(`"yesterday"`), &v.A))
+}
+
+func TestTestJSONReadOnlyFields(t *testing.T) {
+	assert := assert.New(t)
+
+	test := Test{LastTested: time.Date(2013, 1, 20, 14, 38, 18, 0, time.UTC), DownTimes: 3}
+
+	b, err := json.Marshal(test)
+	assert.NoError(err)
+
+	var decoded Test
+	assert.NoError(json.Unmarshal(b, &decoded))
+	assert.Equal(test.LastTested, decoded.LastTested)
+	assert.Equal(3, decoded.DownTimes)
+	assert.Contains(string(b), `"LastTested":"2013-01-20 14:38:18"`)
+
+	// the list of Tests has DownTimes as a number
+	assert.NoError(json.Unmarshal([]byte(`{"TestID":1,"DownTimes":2}`), &decoded))
+	assert.Equal(2, decoded.DownTimes)
+}